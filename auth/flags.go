@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// ServerFlags are the command line flags setting up the tokens and TLS of a server, see RegisterServerFlags
+type ServerFlags struct {
+	JWTSecret          string
+	JWTPublicKey       string
+	JWTIssuer          string
+	JWTAudience        string
+	TLS                bool
+	MutualTLS          bool
+	CertFile           string
+	KeyFile            string
+	CAFile             string
+	CertReloadInterval time.Duration
+}
+
+// RegisterServerFlags defines the server flags in fs, they are set once fs is parsed
+func RegisterServerFlags(fs *flag.FlagSet) *ServerFlags {
+	f := &ServerFlags{}
+	fs.StringVar(&f.JWTSecret, "jwt-secret", "ssl/jwt_secret", "file holding the secret of HS256 tokens, empty to only accept RS256 tokens")
+	fs.StringVar(&f.JWTPublicKey, "jwt-public-key", "", "PEM file holding the RSA public key of RS256 tokens")
+	fs.StringVar(&f.JWTIssuer, "jwt-issuer", "", "iss claim tokens must carry, empty to accept any issuer")
+	fs.StringVar(&f.JWTAudience, "jwt-audience", "", "audience tokens must be meant for, empty to accept any audience")
+	fs.BoolVar(&f.TLS, "tls", false, "serve over TLS")
+	fs.BoolVar(&f.MutualTLS, "mtls", false, "serve over mutual TLS, only accepting clients with a certificate signed by -ca-file")
+	fs.StringVar(&f.CertFile, "cert-file", "ssl/server.crt", "certificate of the server, used with -tls or -mtls")
+	fs.StringVar(&f.KeyFile, "key-file", "ssl/server.pem", "private key of the server certificate, used with -tls or -mtls")
+	fs.StringVar(&f.CAFile, "ca-file", "ssl/ca.crt", "authority the client certificates are verified against, used with -mtls")
+	fs.DurationVar(&f.CertReloadInterval, "cert-reload-interval", 10*time.Second, "how often to check -cert-file and -key-file for a new certificate, 0 to never reload it")
+	return f
+}
+
+// ServerOptions returns the options of a server checking tokens and serving TLS as flags say,
+// publicMethods being the methods that can be called without a token as in NewInterceptor.
+// The server certificate is reloaded from its files until ctx is done.
+func ServerOptions(ctx context.Context, flags *ServerFlags, publicMethods ...string) ([]grpc.ServerOption, error) {
+	// the secret is never shipped with the code, each deployment creates its own with certgen
+	verifier, err := LoadVerifier(flags.JWTSecret, flags.JWTPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading JWT keys, run go run ./cmd/certgen to create the secret: %v", err)
+	}
+	verifier.Issuer = flags.JWTIssuer
+	verifier.Audience = flags.JWTAudience
+
+	opts := NewInterceptor(verifier, publicMethods...).ServerOptions()
+	if !flags.TLS && !flags.MutualTLS {
+		return opts, nil
+	}
+	clientCAFile := ""
+	if flags.MutualTLS {
+		clientCAFile = flags.CAFile
+	}
+	certs, err := NewCertReloader(flags.CertFile, flags.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := ServerCredentials(certs, clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	if flags.CertReloadInterval > 0 {
+		go certs.Watch(ctx, flags.CertReloadInterval)
+	}
+	return append(opts, grpc.Creds(creds)), nil
+}
+
+// ClientFlags are the command line flags setting up the tokens and TLS of a client, see RegisterClientFlags
+type ClientFlags struct {
+	JWTSecret   string
+	JWTIssuer   string
+	JWTAudience string
+	TLS         bool
+	MutualTLS   bool
+	CAFile      string
+	CertFile    string
+	KeyFile     string
+}
+
+// RegisterClientFlags defines the client flags in fs, they are set once fs is parsed
+func RegisterClientFlags(fs *flag.FlagSet) *ClientFlags {
+	f := &ClientFlags{}
+	fs.StringVar(&f.JWTSecret, "jwt-secret", "ssl/jwt_secret", "file holding the secret shared with the server, to sign development tokens")
+	fs.StringVar(&f.JWTIssuer, "jwt-issuer", "", "iss claim of the development tokens, to match the -jwt-issuer of the server")
+	fs.StringVar(&f.JWTAudience, "jwt-audience", "", "audience of the development tokens, to match the -jwt-audience of the server")
+	fs.BoolVar(&f.TLS, "tls", false, "connect over TLS, trusting the servers signed by -ca-file")
+	fs.BoolVar(&f.MutualTLS, "mtls", false, "connect over mutual TLS, presenting the certificate in -cert-file")
+	fs.StringVar(&f.CAFile, "ca-file", "ssl/ca.crt", "authority the server certificate is verified against, used with -tls or -mtls")
+	fs.StringVar(&f.CertFile, "cert-file", "ssl/client.crt", "certificate of the client, used with -mtls")
+	fs.StringVar(&f.KeyFile, "key-file", "ssl/client.pem", "private key of the client certificate, used with -mtls")
+	return f
+}
+
+// Token returns token, or a development token of subject signed with -jwt-secret when token is empty
+func (f *ClientFlags) Token(token, subject string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+	claims := Claims{Subject: subject, Issuer: f.JWTIssuer}
+	if f.JWTAudience != "" {
+		claims.Audience = Audience{f.JWTAudience}
+	}
+	devToken, err := DevToken(f.JWTSecret, claims)
+	if err != nil {
+		return "", fmt.Errorf("signing a development token: %v", err)
+	}
+	return devToken, nil
+}
+
+// DialOptions returns the options of a client connecting over TLS as flags say and sending token with every call.
+// Without TLS the token is sent in the clear, which is only fine for local development.
+func DialOptions(flags *ClientFlags, token string) ([]grpc.DialOption, error) {
+	secure := flags.TLS || flags.MutualTLS
+	opts := []grpc.DialOption{grpc.WithPerRPCCredentials(TokenCredentials{Token: token, Insecure: !secure})}
+	if !secure {
+		return append(opts, grpc.WithInsecure()), nil
+	}
+	certFile, keyFile := "", ""
+	if flags.MutualTLS {
+		certFile, keyFile = flags.CertFile, flags.KeyFile
+	}
+	creds, err := ClientCredentials(flags.CAFile, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, grpc.WithTransportCredentials(creds)), nil
+}
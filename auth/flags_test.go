@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth-flags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "jwt_secret")
+	if err := ioutil.WriteFile(secretFile, []byte("auth-test-secret-0123456789abcdef\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	serverFlags := func(args ...string) *ServerFlags {
+		fs := flag.NewFlagSet("server", flag.ContinueOnError)
+		f := RegisterServerFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	servers := []struct {
+		name  string
+		flags *ServerFlags
+		want  int
+	}{
+		{"plaintext", serverFlags("-jwt-secret", secretFile), 2},
+		{"missing secret", serverFlags("-jwt-secret", missing), 0},
+		{"missing certificate", serverFlags("-jwt-secret", secretFile, "-tls", "-cert-file", missing), 0},
+	}
+	for _, tt := range servers {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ServerOptions(context.Background(), tt.flags)
+			if len(opts) != tt.want || (err == nil) != (tt.want > 0) {
+				t.Errorf("ServerOptions() = %v options, %v, want %v options", len(opts), err, tt.want)
+			}
+		})
+	}
+
+	fs := flag.NewFlagSet("client", flag.ContinueOnError)
+	client := RegisterClientFlags(fs)
+	if err := fs.Parse([]string{"-jwt-secret", secretFile, "-jwt-issuer", "blog", "-jwt-audience", "readers"}); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := client.Token("given", "alice"); token != "given" || err != nil {
+		t.Errorf("Token() = %q, %v, want the given token", token, err)
+	}
+	token, err := client.Token("", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := LoadVerifier(secretFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.Issuer = "blog"
+	verifier.Audience = "readers"
+	if claims, err := verifier.Verify(token); err != nil || claims.Subject != "alice" {
+		t.Errorf("Verify(development token) = %v, %v, want a token of alice", claims, err)
+	}
+
+	if opts, err := DialOptions(client, token); len(opts) != 2 || err != nil {
+		t.Errorf("DialOptions() = %v options, %v, want 2", len(opts), err)
+	}
+	client.TLS = true
+	client.CAFile = missing
+	if _, err := DialOptions(client, token); err == nil {
+		t.Error("DialOptions() with a missing CA succeeded")
+	}
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ReflectionMethods are the methods of the server reflection service,
+// which servers usually keep public so tools can discover their API
+var ReflectionMethods = []string{
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+}
+
+// Interceptor verifies the token sent in the "authorization: Bearer <token>" metadata of every call
+// and hands its claims to the handlers through the context, see FromContext.
+// On mutual TLS connections, calls without a token are made by the identity of the client certificate.
+// Calls without a valid token fail with codes.Unauthenticated, except anonymous calls to public methods.
+type Interceptor struct {
+	verifier *Verifier
+	public   map[string]bool
+}
+
+// NewInterceptor returns an Interceptor checking tokens with verifier.
+// publicMethods are full method names, such as "/blog.BlogService/ReadBlog", that can be called without a token.
+func NewInterceptor(verifier *Verifier, publicMethods ...string) *Interceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+	return &Interceptor{verifier: verifier, public: public}
+}
+
+// ServerOptions returns the options installing both interceptors on a server
+func (i *Interceptor) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(i.Unary),
+		grpc.StreamInterceptor(i.Stream),
+	}
+}
+
+// Unary is a grpc.UnaryServerInterceptor
+func (i *Interceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Stream is a grpc.StreamServerInterceptor
+func (i *Interceptor) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate adds the claims of the caller to ctx.
+// A token sent to a public method must still be valid, so callers are never silently treated as anonymous.
+// A token takes precedence over the client certificate, so users can call through a shared trusted client.
+func (i *Interceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		if identity, ok := PeerIdentityFromContext(ctx); ok {
+			return NewContext(ctx, &Claims{Subject: identity.Name()}), nil
+		}
+		if i.public[method] {
+			return ctx, nil
+		}
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"Missing authorization token",
+		)
+	}
+	token, ok := bearerToken(values[0])
+	if !ok {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"Invalid authorization metadata, expected a Bearer token",
+		)
+	}
+	claims, err := i.verifier.Verify(token)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"Invalid authorization token: %v", err,
+		)
+	}
+	return NewContext(ctx, claims), nil
+}
+
+// bearerToken extracts the token from the value of the authorization metadata
+func bearerToken(value string) (string, bool) {
+	const prefix = "bearer "
+	if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return "", false
+	}
+	return value[len(prefix):], true
+}
+
+// authenticatedStream hands the context carrying the claims to stream handlers
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,275 @@
+// Package auth authenticates gRPC calls with JSON Web Tokens (JWT).
+//
+// Servers install the interceptors of an Interceptor to verify the token of every call,
+// and clients send their token with TokenCredentials.
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMalformedToken is returned when a token is not a well-formed JWT
+	ErrMalformedToken = errors.New("malformed token")
+	// ErrUnsupportedAlgorithm is returned when a token is signed with an algorithm the Verifier has no key for
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	// ErrInvalidSignature is returned when the signature of a token does not match its content
+	ErrInvalidSignature = errors.New("invalid token signature")
+	// ErrTokenExpired is returned when a token is past its expiration time
+	ErrTokenExpired = errors.New("token expired")
+	// ErrMissingExpiration is returned when a token has no expiration time, tokens valid forever are refused
+	ErrMissingExpiration = errors.New("token has no expiration time")
+	// ErrTokenNotValidYet is returned when a token is used before its not before time
+	ErrTokenNotValidYet = errors.New("token not valid yet")
+	// ErrInvalidIssuer is returned when a token was not issued by the expected issuer
+	ErrInvalidIssuer = errors.New("invalid token issuer")
+	// ErrInvalidAudience is returned when a token is not meant for the expected audience
+	ErrInvalidAudience = errors.New("invalid token audience")
+)
+
+const (
+	// leeway tolerates some clock skew between the issuer of a token and the server checking it
+	leeway = time.Minute
+	// minSecretLength is the shortest HMAC secret accepted, as long as the SHA-256 output
+	minSecretLength = 32
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Verifier checks the signature and the validity period of tokens
+// signed with HS256 by a shared secret or with RS256 by an RSA key.
+type Verifier struct {
+	hmacSecret []byte
+	rsaKey     *rsa.PublicKey
+	// Issuer and Audience are checked against the claims of tokens when not empty
+	Issuer   string
+	Audience string
+	// now returns the current time, time.Now when nil
+	now func() time.Time
+}
+
+// NewVerifier returns a Verifier accepting HS256 tokens when hmacSecret is set
+// and RS256 tokens when rsaKey is set. At least one of them is needed.
+func NewVerifier(hmacSecret []byte, rsaKey *rsa.PublicKey) (*Verifier, error) {
+	if len(hmacSecret) == 0 && rsaKey == nil {
+		return nil, errors.New("a verifier needs an HMAC secret or an RSA public key")
+	}
+	return &Verifier{hmacSecret: hmacSecret, rsaKey: rsaKey}, nil
+}
+
+// LoadVerifier returns a Verifier using the HMAC secret and the PEM encoded RSA public key in the given files,
+// either of them can be empty to only accept the other algorithm
+func LoadVerifier(secretFile, publicKeyFile string) (*Verifier, error) {
+	var secret []byte
+	if secretFile != "" {
+		s, err := LoadSecret(secretFile)
+		if err != nil {
+			return nil, err
+		}
+		secret = s
+	}
+	var key *rsa.PublicKey
+	if publicKeyFile != "" {
+		k, err := LoadRSAPublicKey(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		key = k
+	}
+	return NewVerifier(secret, key)
+}
+
+// Verify parses a token and returns its claims if it is valid
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrMalformedToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	// the algorithm must match the kind of key, so an RSA public key is never used as an HMAC secret
+	signed := []byte(parts[0] + "." + parts[1])
+	switch {
+	case h.Alg == "HS256" && len(v.hmacSecret) > 0:
+		mac := hmac.New(sha256.New, v.hmacSecret)
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return nil, ErrInvalidSignature
+		}
+	case h.Alg == "RS256" && v.rsaKey != nil:
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(v.rsaKey, crypto.SHA256, digest[:], sig); err != nil {
+			return nil, ErrInvalidSignature
+		}
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	claims := &Claims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Verifier) validate(claims *Claims) error {
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+	if claims.ExpiresAt == 0 {
+		return ErrMissingExpiration
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(leeway)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Before(time.Unix(claims.NotBefore, 0).Add(-leeway)) {
+		return ErrTokenNotValidYet
+	}
+	if v.Issuer != "" && claims.Issuer != v.Issuer {
+		return ErrInvalidIssuer
+	}
+	if v.Audience != "" && !claims.Audience.contains(v.Audience) {
+		return ErrInvalidAudience
+	}
+	if claims.Subject == "" {
+		return fmt.Errorf("%v: missing subject", ErrMalformedToken)
+	}
+	return nil
+}
+
+// Sign returns a token carrying claims, signed with HS256 when key is a []byte secret
+// or with RS256 when it is an *rsa.PrivateKey
+func Sign(claims *Claims, key interface{}) (string, error) {
+	var h header
+	switch key.(type) {
+	case []byte:
+		h = header{Alg: "HS256", Typ: "JWT"}
+	case *rsa.PrivateKey:
+		h = header{Alg: "RS256", Typ: "JWT"}
+	default:
+		return "", fmt.Errorf("unsupported signing key type %T", key)
+	}
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	cb, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(cb)
+
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		if sig, err = rsa.SignPKCS1v15(nil, k, crypto.SHA256, digest[:]); err != nil {
+			return "", err
+		}
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// LoadSecret reads an HMAC secret from a file, ignoring surrounding white space.
+// Secrets shorter than minSecretLength are refused, they could be guessed.
+func LoadSecret(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	secret := []byte(strings.TrimSpace(string(b)))
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("secret in %v is too short, it needs at least %v characters", path, minSecretLength)
+	}
+	return secret, nil
+}
+
+// LoadRSAPublicKey reads a PEM encoded RSA public key, in PKIX or PKCS #1 form
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%v does not hold an RSA public key", path)
+	}
+	return rsaKey, nil
+}
+
+// LoadRSAPrivateKey reads a PEM encoded RSA private key, in PKCS #8 or PKCS #1 form
+func LoadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%v does not hold an RSA private key", path)
+	}
+	return rsaKey, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %v", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("auth-test-secret-0123456789abcdef")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour).Unix()
+	sign := func(claims *Claims, key interface{}) string {
+		token, err := Sign(claims, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	valid := sign(&Claims{Subject: "alice", Issuer: "blog", Audience: Audience{"blog"}, ExpiresAt: later}, secret)
+
+	tests := []struct {
+		name     string
+		token    string
+		rsa      bool
+		issuer   string
+		audience string
+		want     error
+	}{
+		{name: "hs256", token: valid},
+		{name: "rs256", token: sign(&Claims{Subject: "alice", ExpiresAt: later}, rsaKey), rsa: true},
+		{name: "matching issuer and audience", token: valid, issuer: "blog", audience: "blog"},
+		{name: "malformed", token: "nope", want: ErrMalformedToken},
+		{name: "tampered", token: valid + "x", want: ErrInvalidSignature},
+		{name: "other secret", token: sign(&Claims{Subject: "alice", ExpiresAt: later}, []byte("another-secret-0123456789abcdef")), want: ErrInvalidSignature},
+		{name: "hs256 on an rsa verifier", token: valid, rsa: true, want: ErrUnsupportedAlgorithm},
+		{name: "no expiration", token: sign(&Claims{Subject: "alice"}, secret), want: ErrMissingExpiration},
+		{name: "expired", token: sign(&Claims{Subject: "alice", ExpiresAt: now.Add(-time.Hour).Unix()}, secret), want: ErrTokenExpired},
+		{name: "not valid yet", token: sign(&Claims{Subject: "alice", NotBefore: later, ExpiresAt: later}, secret), want: ErrTokenNotValidYet},
+		{name: "other issuer", token: valid, issuer: "calculator", want: ErrInvalidIssuer},
+		{name: "other audience", token: valid, audience: "calculator", want: ErrInvalidAudience},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _ := NewVerifier(secret, nil)
+			if tt.rsa {
+				v, _ = NewVerifier(nil, &rsaKey.PublicKey)
+			}
+			v.Issuer = tt.issuer
+			v.Audience = tt.audience
+			v.now = func() time.Time { return now }
+
+			claims, err := v.Verify(tt.token)
+			if err != tt.want {
+				t.Fatalf("Verify() = %v, want %v", err, tt.want)
+			}
+			if err == nil && claims.Subject != "alice" {
+				t.Errorf("Verify() subject = %q, want alice", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestLoadSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{"trimmed", "  0123456789abcdef0123456789abcdef\n", "0123456789abcdef0123456789abcdef", false},
+		{"too short", "secret\n", "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			secret, err := LoadSecret(path)
+			if (err != nil) != tt.wantErr || string(secret) != tt.want {
+				t.Errorf("LoadSecret() = %q, %v, want %q, error %v", secret, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if _, err := LoadSecret(filepath.Join(dir, "missing")); err == nil {
+		t.Error("LoadSecret(missing file) succeeded")
+	}
+}
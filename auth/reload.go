@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CertReloader hands out the certificate in a cert/key pair of files to new TLS handshakes
+// and swaps it when Watch sees the files change, so certificates are rotated without a restart.
+// Connections already established keep the certificate they were set up with.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	cert atomic.Value // *tls.Certificate
+
+	mu       sync.Mutex
+	lastSeen string // stat of the files last loaded or refused, to only try a changed pair once
+}
+
+// NewCertReloader loads the certificate in certFile and its private key in keyFile
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	r.lastSeen = r.stat()
+	cert, err := loadCertificate(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	r.cert.Store(cert)
+	return r, nil
+}
+
+// GetCertificate is meant for tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load().(*tls.Certificate), nil
+}
+
+// Watch reloads the certificate when its files change, checking every interval until ctx is done.
+// A pair that does not load, such as a certificate not matching its key, is logged and refused,
+// the current certificate being kept until the files change again.
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := r.Reload(); err != nil {
+			log.Printf("Keeping the current TLS certificate: %v", err)
+		}
+	}
+}
+
+// Reload swaps in the certificate in the files if they changed since they were last looked at,
+// and tells if it did
+func (r *CertReloader) Reload() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := r.stat()
+	if seen == r.lastSeen {
+		return false, nil
+	}
+	r.lastSeen = seen
+	cert, err := loadCertificate(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+	r.cert.Store(cert)
+	log.Printf("Reloaded TLS certificate %v, valid until %v", r.certFile, cert.Leaf.NotAfter.Format(time.RFC3339))
+	return true, nil
+}
+
+// stat sums up the size and modification time of both files, missing files included,
+// so any write to them changes it
+func (r *CertReloader) stat() string {
+	sum := ""
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			sum += "missing;"
+			continue
+		}
+		sum += fmt.Sprintf("%v:%v;", info.Size(), info.ModTime().UnixNano())
+	}
+	return sum
+}
+
+// loadCertificate reads a cert/key pair and checks the certificate can currently be used
+func loadCertificate(certFile, keyFile string) (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading certificate %v: %v", certFile, err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate %v: %v", certFile, err)
+	}
+	now := time.Now()
+	if now.After(leaf.NotAfter) {
+		return nil, fmt.Errorf("certificate %v expired on %v", certFile, leaf.NotAfter.Format(time.RFC3339))
+	}
+	if now.Before(leaf.NotBefore) {
+		return nil, fmt.Errorf("certificate %v is not valid before %v", certFile, leaf.NotBefore.Format(time.RFC3339))
+	}
+	cert.Leaf = leaf
+	return &cert, nil
+}
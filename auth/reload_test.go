@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// touch gives a file a new modification time, so a rewrite within the same clock tick is still seen as a change
+func touch(t *testing.T, file string, at time.Time) {
+	t.Helper()
+	if err := os.Chtimes(file, at, at); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// copyFile overwrites dst with the content of src
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(dst, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// servedName returns the common name of the certificate the server presents to a new handshake
+func servedName(certs *CertReloader) string {
+	cert, _ := certs.GetCertificate(nil)
+	return cert.Leaf.Subject.CommonName
+}
+
+func TestCertReloaderReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth-reload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	pki := newTestPKI(t, dir, "ca")
+	certFile, keyFile := pki.server("server")
+	if _, err := NewCertReloader(certFile, filepath.Join(dir, "missing.key")); err == nil {
+		t.Error("NewCertReloader() without a key succeeded")
+	}
+	certs, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Now()
+	next := func() time.Time {
+		at = at.Add(time.Second)
+		return at
+	}
+
+	// each step changes the files, then checks what Reload makes of them
+	steps := []struct {
+		name     string
+		change   func()
+		reloaded bool
+		wantErr  bool
+		want     string
+	}{
+		{
+			name:   "unchanged",
+			change: func() {},
+			want:   "server",
+		},
+		{
+			name: "rotated",
+			change: func() {
+				pki.issue("server", &x509.Certificate{Subject: pkix.Name{CommonName: "rotated"}, DNSNames: []string{"bufnet"}})
+				touch(t, certFile, next())
+			},
+			reloaded: true,
+			want:     "rotated",
+		},
+		{
+			name: "key of another certificate",
+			change: func() {
+				_, otherKey := pki.server("other")
+				copyFile(t, otherKey, keyFile)
+				touch(t, keyFile, next())
+			},
+			wantErr: true,
+			want:    "rotated",
+		},
+		{
+			name:   "refused pair is only tried once",
+			change: func() {},
+			want:   "rotated",
+		},
+		{
+			name: "expired",
+			change: func() {
+				pki.issue("server", &x509.Certificate{
+					Subject:   pkix.Name{CommonName: "expired"},
+					NotBefore: time.Now().Add(-2 * time.Hour),
+					NotAfter:  time.Now().Add(-time.Hour),
+				})
+				touch(t, certFile, next())
+			},
+			wantErr: true,
+			want:    "rotated",
+		},
+		{
+			name: "half written",
+			change: func() {
+				if err := os.Remove(keyFile); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: true,
+			want:    "rotated",
+		},
+		{
+			name: "fixed",
+			change: func() {
+				pki.issue("server", &x509.Certificate{Subject: pkix.Name{CommonName: "fixed"}, DNSNames: []string{"bufnet"}})
+				touch(t, certFile, next())
+			},
+			reloaded: true,
+			want:     "fixed",
+		},
+	}
+	for _, step := range steps {
+		step.change()
+		reloaded, err := certs.Reload()
+		if reloaded != step.reloaded || (err != nil) != step.wantErr {
+			t.Errorf("%v: Reload() = %v, %v, want %v, error %v", step.name, reloaded, err, step.reloaded, step.wantErr)
+		}
+		if got := servedName(certs); got != step.want {
+			t.Errorf("%v: serving certificate %q, want %q", step.name, got, step.want)
+		}
+	}
+}
+
+// handshakeName connects to lis and returns the common name of the certificate the server presented
+func handshakeName(t *testing.T, lis *bufconn.Listener, creds credentials.TransportCredentials, token string) string {
+	t.Helper()
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+	cc, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(creds), grpc.WithContextDialer(dialer), grpc.WithPerRPCCredentials(TokenCredentials{Token: token}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	return peerName(t, cc)
+}
+
+// peerName makes a call on cc and returns the common name of the certificate the server presented on it
+func peerName(t *testing.T, cc *grpc.ClientConn) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var p peer.Peer
+	if _, err := healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p)); err != nil {
+		t.Fatalf("Check() = %v", err)
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		t.Fatalf("Check() was not made over TLS: %v", p.AuthInfo)
+	}
+	return info.State.PeerCertificates[0].Subject.CommonName
+}
+
+func TestCertReloaderWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth-watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	secret := []byte("auth-test-secret-0123456789abcdef")
+	token, err := Sign(&Claims{Subject: "alice", ExpiresAt: time.Now().Add(time.Hour).Unix()}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pki := newTestPKI(t, dir, "ca")
+	certFile, _ := pki.server("server")
+	certs, err := NewCertReloader(certFile, filepath.Join(dir, "server.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverCreds, err := ServerCredentials(certs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := serveTLS(t, serverCreds, secret)
+	clientCreds, err := ClientCredentials(pki.CAFile, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a connection opened before the rotation keeps working with the certificate it was set up with
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+	open, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(clientCreds), grpc.WithContextDialer(dialer), grpc.WithPerRPCCredentials(TokenCredentials{Token: token}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer open.Close()
+	if got := peerName(t, open); got != "server" {
+		t.Fatalf("served certificate %q, want server", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		certs.Watch(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	pki.issue("server", &x509.Certificate{Subject: pkix.Name{CommonName: "rotated"}, DNSNames: []string{"bufnet"}})
+	touch(t, certFile, time.Now().Add(time.Second))
+	deadline := time.Now().Add(5 * time.Second)
+	for handshakeName(t, lis, clientCreds, token) != "rotated" {
+		if time.Now().After(deadline) {
+			t.Fatal("new handshakes never got the rotated certificate")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := peerName(t, open); got != "server" {
+		t.Errorf("open connection switched to certificate %q, want it to keep server", got)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch() did not return once its context was done")
+	}
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ServerCredentials returns the TLS credentials of a server presenting the current certificate of certs.
+// When clientCAFile is set the server runs mutual TLS: clients must present a certificate
+// signed by one of the authorities in clientCAFile, others are rejected during the handshake.
+func ServerCredentials(certs *CertReloader, clientCAFile string) (credentials.TransportCredentials, error) {
+	config := &tls.Config{
+		GetCertificate: certs.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pool, err := loadCertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// ClientCredentials returns the TLS credentials of a client trusting the authorities in caFile.
+// When certFile is set the client presents that certificate to servers running mutual TLS.
+func ClientCredentials(caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	pool, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA certificates: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no CA certificate found in %v", file)
+	}
+	return pool, nil
+}
+
+// PeerIdentity is who a client certificate verified during a mutual TLS handshake was issued to
+type PeerIdentity struct {
+	Subject        pkix.Name
+	DNSNames       []string
+	EmailAddresses []string
+	URIs           []string
+}
+
+// Name returns the identity of the peer: the first URI, DNS or email subject alternative name,
+// in that order, falling back to the common name of the subject
+func (p *PeerIdentity) Name() string {
+	for _, names := range [][]string{p.URIs, p.DNSNames, p.EmailAddresses} {
+		if len(names) > 0 {
+			return names[0]
+		}
+	}
+	return p.Subject.CommonName
+}
+
+// PeerIdentityFromContext returns the identity of the client certificate of a call,
+// or false when the connection did not use mutual TLS
+func PeerIdentityFromContext(ctx context.Context) (*PeerIdentity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	cert := info.State.VerifiedChains[0][0]
+	identity := &PeerIdentity{
+		Subject:        cert.Subject,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+	return identity, true
+}
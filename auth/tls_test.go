@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testPKI is a throwaway certificate authority issuing certificates into a temporary directory
+type testPKI struct {
+	t      *testing.T
+	dir    string
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	CAFile string
+}
+
+func newTestPKI(t *testing.T, dir, name string) *testPKI {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &testPKI{t: t, dir: dir, cert: cert, key: key, CAFile: filepath.Join(dir, name+".crt")}
+	p.write(p.CAFile, "CERTIFICATE", der)
+	return p
+}
+
+// issue signs a certificate for template, writing it and its key to name.crt and name.key
+func (p *testPKI) issue(name string, template *x509.Certificate) (certFile, keyFile string) {
+	p.t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	template.SerialNumber = serial
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(time.Hour)
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, p.cert, &key.PublicKey, p.key)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	certFile, keyFile = filepath.Join(p.dir, name+".crt"), filepath.Join(p.dir, name+".key")
+	p.write(certFile, "CERTIFICATE", der)
+	p.write(keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+// server issues a server certificate for the "bufnet" host the test clients dial
+func (p *testPKI) server(name string) (certFile, keyFile string) {
+	return p.issue(name, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		DNSNames:    []string{"bufnet"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+}
+
+// client issues a client certificate for template
+func (p *testPKI) client(name string, template *x509.Certificate) (certFile, keyFile string) {
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	return p.issue(name, template)
+}
+
+func (p *testPKI) write(file, blockType string, der []byte) {
+	p.t.Helper()
+	if err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		p.t.Fatal(err)
+	}
+}
+
+// subjectServer answers health checks, sending the subject of the caller in the "subject" header
+type subjectServer struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (subjectServer) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if claims, ok := FromContext(ctx); ok {
+		grpc.SetHeader(ctx, metadata.Pairs("subject", claims.Subject))
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// serveTLS serves subjectServer with creds over an in-memory listener, behind an Interceptor checking tokens with secret
+func serveTLS(t *testing.T, creds credentials.TransportCredentials, secret []byte) *bufconn.Listener {
+	t.Helper()
+	verifier, err := NewVerifier(secret, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(append(NewInterceptor(verifier).ServerOptions(), grpc.Creds(creds))...)
+	healthpb.RegisterHealthServer(s, subjectServer{})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis
+}
+
+// checkSubject calls lis over creds, sending token unless it is empty, and returns the subject the server saw
+func checkSubject(lis *bufconn.Listener, creds credentials.TransportCredentials, token string) (string, error) {
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds), grpc.WithContextDialer(dialer)}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(TokenCredentials{Token: token}))
+	}
+	cc, err := grpc.Dial("bufnet", opts...)
+	if err != nil {
+		return "", err
+	}
+	defer cc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var header metadata.MD
+	if _, err := healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		return "", err
+	}
+	if subject := header.Get("subject"); len(subject) > 0 {
+		return subject[0], nil
+	}
+	return "", nil
+}
+
+func TestMutualTLSIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	secret := []byte("auth-test-secret-0123456789abcdef")
+	pki := newTestPKI(t, dir, "ca")
+	rogue := newTestPKI(t, dir, "rogue-ca")
+
+	certs, err := NewCertReloader(pki.server("server"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtls, err := ServerCredentials(certs, pki.CAFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlsOnly, err := ServerCredentials(certs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtlsLis := serveTLS(t, mtls, secret)
+	tlsLis := serveTLS(t, tlsOnly, secret)
+
+	spiffe, err := url.Parse("spiffe://example.org/alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clients := map[string]*x509.Certificate{
+		"alice": {Subject: pkix.Name{CommonName: "alice"}},
+		"uri":   {Subject: pkix.Name{CommonName: "ignored"}, URIs: []*url.URL{spiffe}, DNSNames: []string{"alice.example.org"}},
+	}
+	creds := map[string]credentials.TransportCredentials{}
+	for name, template := range clients {
+		certFile, keyFile := pki.client(name, template)
+		if creds[name], err = ClientCredentials(pki.CAFile, certFile, keyFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+	certFile, keyFile := rogue.client("rogue", &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}})
+	if creds["rogue"], err = ClientCredentials(pki.CAFile, certFile, keyFile); err != nil {
+		t.Fatal(err)
+	}
+	if creds["none"], err = ClientCredentials(pki.CAFile, "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(subject string, key []byte) string {
+		token, err := Sign(&Claims{Subject: subject, ExpiresAt: time.Now().Add(time.Hour).Unix()}, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	bob := sign("bob", secret)
+	forged := sign("bob", []byte("another-secret-0123456789abcdef!"))
+
+	tests := []struct {
+		name   string
+		lis    *bufconn.Listener
+		client string
+		token  string
+		want   string
+		code   codes.Code
+	}{
+		{name: "certificate identity", lis: mtlsLis, client: "alice", want: "alice"},
+		{name: "uri before dns and common name", lis: mtlsLis, client: "uri", want: "spiffe://example.org/alice"},
+		{name: "token over the certificate", lis: mtlsLis, client: "alice", token: bob, want: "bob"},
+		{name: "invalid token does not fall back to the certificate", lis: mtlsLis, client: "alice", token: forged, code: codes.Unauthenticated},
+		{name: "no client certificate", lis: mtlsLis, client: "none", code: codes.Unavailable},
+		{name: "certificate of another authority", lis: mtlsLis, client: "rogue", code: codes.Unavailable},
+		{name: "unverified certificate on plain TLS", lis: tlsLis, client: "alice", code: codes.Unauthenticated},
+		{name: "token on plain TLS", lis: tlsLis, client: "none", token: bob, want: "bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkSubject(tt.lis, creds[tt.client], tt.token)
+			if status.Code(err) != tt.code || got != tt.want {
+				t.Errorf("Check() saw %q, %v, want %q, %v", got, err, tt.want, tt.code)
+			}
+		})
+	}
+}
+
+func TestPeerIdentityName(t *testing.T) {
+	tests := []struct {
+		identity PeerIdentity
+		want     string
+	}{
+		{PeerIdentity{Subject: pkix.Name{CommonName: "cn"}}, "cn"},
+		{PeerIdentity{Subject: pkix.Name{CommonName: "cn"}, EmailAddresses: []string{"a@example.org"}}, "a@example.org"},
+		{PeerIdentity{EmailAddresses: []string{"a@example.org"}, DNSNames: []string{"a.example.org", "b.example.org"}}, "a.example.org"},
+		{PeerIdentity{DNSNames: []string{"a.example.org"}, URIs: []string{"spiffe://example.org/a"}}, "spiffe://example.org/a"},
+		{PeerIdentity{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.identity.Name(); got != tt.want {
+			t.Errorf("%+v.Name() = %q, want %q", tt.identity, got, tt.want)
+		}
+	}
+}
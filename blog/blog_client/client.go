@@ -2,36 +2,50 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 
+	"github.com/simplesteph/grpc-go-course/auth"
 	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc"
 )
 
 func main() {
 
-	fmt.Println("Blog Client")
+	authorToken := flag.String("token", "", "JWT of the author, a development token is signed with -jwt-secret when empty")
+	readerToken := flag.String("reader-token", "", "JWT of the reader commenting on the blog, a development token is signed with -jwt-secret when empty")
+	authFlags := auth.RegisterClientFlags(flag.CommandLine)
+	flag.Parse()
 
-	opts := grpc.WithInsecure()
+	fmt.Println("Blog Client")
 
-	cc, err := grpc.Dial("localhost:50051", opts)
+	// the server knows who we are from the token sent along with every call
+	cc, err := dial(authFlags, *authorToken, "Stephane")
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
 	defer cc.Close() // Maybe this should be in a separate function and the error handled?
 
+	readerCc, err := dial(authFlags, *readerToken, "Reader")
+	if err != nil {
+		log.Fatalf("could not connect: %v", err)
+	}
+	defer readerCc.Close()
+
 	c := blogpb.NewBlogServiceClient(cc)
+	reader := blogpb.NewBlogServiceClient(readerCc)
+	ctx := context.Background()
 
 	// create Blog
 	fmt.Println("Creating the blog")
 	blog := &blogpb.Blog{
-		AuthorId: "Stephane",
-		Title:    "My First Blog",
-		Content:  "Content of the first blog",
+		Title:   "My First Blog",
+		Content: "Content of the first blog",
 	}
-	createBlogRes, err := c.CreateBlog(context.Background(), &blogpb.CreateBlogRequest{Blog: blog})
+	createBlogRes, err := c.CreateBlog(ctx, &blogpb.CreateBlogRequest{Blog: blog})
 	if err != nil {
 		log.Fatalf("Unexpected error: %v", err)
 	}
@@ -41,13 +55,13 @@ func main() {
 	// read Blog
 	fmt.Println("Reading the blog")
 
-	_, err2 := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: "5bdc29e661b75adcac496cf4"})
+	_, err2 := c.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: "5bdc29e661b75adcac496cf4"})
 	if err2 != nil {
 		fmt.Printf("Error happened while reading: %v \n", err2)
 	}
 
 	readBlogReq := &blogpb.ReadBlogRequest{BlogId: blogID}
-	readBlogRes, readBlogErr := c.ReadBlog(context.Background(), readBlogReq)
+	readBlogRes, readBlogErr := c.ReadBlog(ctx, readBlogReq)
 	if readBlogErr != nil {
 		fmt.Printf("Error happened while reading: %v \n", readBlogErr)
 	}
@@ -57,30 +71,86 @@ func main() {
 	// update Blog
 	newBlog := &blogpb.Blog{
 		Id:       blogID,
-		AuthorId: "Changed Author",
+		Version:  readBlogRes.GetBlog().GetVersion(),  // the update fails if someone changed the blog since we read it
+		AuthorId: readBlogRes.GetBlog().GetAuthorId(), // only admins can change it
 		Title:    "My First Blog (edited)",
 		Content:  "Content of the first blog, with some awesome additions!",
 	}
-	updateRes, updateErr := c.UpdateBlog(context.Background(), &blogpb.UpdateBlogRequest{Blog: newBlog})
+	updateRes, updateErr := c.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: newBlog})
 	if updateErr != nil {
 		fmt.Printf("Error happened while updating: %v \n", updateErr)
 	}
 	fmt.Printf("Blog was updated: %v\n", updateRes)
 
+	// update only the title of the Blog
+	titleRes, titleErr := c.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+		Blog: &blogpb.Blog{
+			Id:      blogID,
+			Version: updateRes.GetBlog().GetVersion(),
+			Title:   "My First Blog (edited twice)",
+		},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+	})
+	if titleErr != nil {
+		fmt.Printf("Error happened while updating the title: %v \n", titleErr)
+	}
+	fmt.Printf("Blog title was updated: %v\n", titleRes)
+
+	// publish Blog, it was created as a draft that readers cannot list
+	publishRes, publishErr := c.PublishBlog(ctx, &blogpb.PublishBlogRequest{BlogId: blogID})
+	if publishErr != nil {
+		fmt.Printf("Error happened while publishing: %v \n", publishErr)
+	}
+	fmt.Printf("Blog was published: %v\n", publishRes)
+
+	// comment on Blog
+	commentRes, commentErr := reader.CreateComment(ctx, &blogpb.CreateCommentRequest{
+		Comment: &blogpb.Comment{
+			BlogId:  blogID,
+			Content: "Great first blog!",
+		},
+	})
+	if commentErr != nil {
+		fmt.Printf("Error happened while commenting: %v \n", commentErr)
+	}
+	fmt.Printf("Comment was created: %v\n", commentRes)
+
+	// the reader is new, so the comment waits for a moderator
+	moderateRes, moderateErr := c.ModerateComment(ctx, &blogpb.ModerateCommentRequest{
+		BlogId:    blogID,
+		CommentId: commentRes.GetComment().GetId(),
+		State:     blogpb.Comment_APPROVED,
+	})
+	if moderateErr != nil {
+		fmt.Printf("Error happened while moderating: %v \n", moderateErr)
+	}
+	fmt.Printf("Comment was moderated: %v\n", moderateRes)
+
 	// delete Blog
-	deleteRes, deleteErr := c.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{BlogId: blogID})
+	deleteRes, deleteErr := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: blogID})
 
 	if deleteErr != nil {
 		fmt.Printf("Error happened while deleting: %v \n", deleteErr)
 	}
 	fmt.Printf("Blog was deleted: %v \n", deleteRes)
 
-	// list Blogs
+	// list Blogs, one page at a time
+	pageToken := ""
+	for {
+		pageToken = listBlogPage(c, pageToken)
+		if pageToken == "" {
+			break
+		}
+	}
+}
 
-	stream, err := c.ListBlog(context.Background(), &blogpb.ListBlogRequest{})
+// listBlogPage prints one page of blogs and returns the token of the next page
+func listBlogPage(c blogpb.BlogServiceClient, pageToken string) string {
+	stream, err := c.ListBlog(context.Background(), &blogpb.ListBlogRequest{PageSize: 10, PageToken: pageToken})
 	if err != nil {
 		log.Fatalf("error while calling ListBlog RPC: %v", err)
 	}
+	nextPageToken := ""
 	for {
 		res, err := stream.Recv()
 		if err == io.EOF {
@@ -90,5 +160,22 @@ func main() {
 			log.Fatalf("Something happened: %v", err)
 		}
 		fmt.Println(res.GetBlog())
+		if res.GetNextPageToken() != "" {
+			nextPageToken = res.GetNextPageToken()
+		}
+	}
+	return nextPageToken
+}
+
+// dial connects to the blog server as the flags say, with token or a development token of subject when it is empty
+func dial(flags *auth.ClientFlags, token, subject string) (*grpc.ClientConn, error) {
+	token, err := flags.Token(token, subject)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := auth.DialOptions(flags, token)
+	if err != nil {
+		return nil, err
 	}
+	return grpc.Dial("localhost:50051", opts...)
 }
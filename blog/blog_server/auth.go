@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/simplesteph/grpc-go-course/auth"
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// adminRole lets a caller change the blogs and comments of every author
+const adminRole = "admin"
+
+// publicMethods can be called without a token: reading is open to everyone, and so is commenting
+var publicMethods = []string{
+	"/blog.BlogService/ReadBlog",
+	"/blog.BlogService/ListBlog",
+	"/blog.BlogService/ListBlogRevisions",
+	"/blog.BlogService/GetBlogRevision",
+	"/blog.BlogService/ListTags",
+	"/blog.BlogService/SearchBlogs",
+	"/blog.BlogService/WatchBlogs",
+	"/blog.BlogService/CreateComment",
+	"/blog.BlogService/ListComments",
+}
+
+// caller is the authenticated identity behind a request, the subject of its token being the author ID
+type caller struct {
+	AuthorID string
+	Admin    bool
+}
+
+// canManage tells if the caller may change what authorID wrote
+func (c *caller) canManage(authorID string) bool {
+	return c.AuthorID == authorID || c.Admin
+}
+
+// canView tells if the caller may see a blog: live published blogs are open to everyone,
+// anonymous callers being nil, and the other ones only to the callers who can manage them
+func (c *caller) canView(data *blogItem) bool {
+	if data.State == blogpb.Blog_PUBLISHED && data.DeleteTime == nil {
+		return true
+	}
+	return c != nil && c.canManage(data.AuthorID)
+}
+
+// callerFromContext returns the caller whose token was verified by the auth interceptors, nil for anonymous requests
+func callerFromContext(ctx context.Context) *caller {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &caller{AuthorID: claims.Subject, Admin: claims.HasRole(adminRole)}
+}
+
+// requireCaller returns the caller of a request that cannot be anonymous
+func requireCaller(ctx context.Context) (*caller, error) {
+	c := callerFromContext(ctx)
+	if c == nil {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"Missing authorization token",
+		)
+	}
+	return c, nil
+}
+
+// requireAuthor returns the caller if it may change what authorID wrote, and an error otherwise
+func requireAuthor(ctx context.Context, authorID string) (*caller, error) {
+	c, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !c.canManage(authorID) {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"Only the author or an admin can do this",
+		)
+	}
+	return c, nil
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+const (
+	// maxCommentLength caps the number of characters in a comment
+	maxCommentLength = 10000
+	// maxCommentDepth is the depth of the deepest reply, top-level comments being at depth 0
+	maxCommentDepth = 4
+)
+
+// commentItem is a comment on a blog, stored apart from the blogs
+type commentItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	BlogID     primitive.ObjectID `bson:"blog_id"`
+	AuthorID   string             `bson:"author_id"`
+	Content    string             `bson:"content"`
+	CreateTime time.Time          `bson:"create_time"`
+	// ParentID is only set on replies
+	ParentID primitive.ObjectID   `bson:"parent_comment_id,omitempty"`
+	Depth    int32                `bson:"depth"`
+	State    blogpb.Comment_State `bson:"state"`
+}
+
+func commentToPb(comment *commentItem) *blogpb.Comment {
+	res := &blogpb.Comment{
+		Id:         comment.ID.Hex(),
+		BlogId:     comment.BlogID.Hex(),
+		AuthorId:   comment.AuthorID,
+		Content:    comment.Content,
+		CreateTime: timeToPb(comment.CreateTime),
+		Depth:      comment.Depth,
+		State:      comment.State,
+	}
+	if !comment.ParentID.IsZero() {
+		res.ParentCommentId = comment.ParentID.Hex()
+	}
+	return res
+}
+
+func (s *server) CreateComment(ctx context.Context, req *blogpb.CreateCommentRequest) (*blogpb.CreateCommentResponse, error) {
+	fmt.Println("Create comment request")
+	comment := req.GetComment()
+	oid, err := primitive.ObjectIDFromHex(comment.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse blog ID",
+		)
+	}
+	content := strings.TrimSpace(comment.GetContent())
+	if content == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Comment content cannot be empty",
+		)
+	}
+	if utf8.RuneCountInString(content) > maxCommentLength {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Comment content is longer than %v characters", maxCommentLength,
+		)
+	}
+
+	// only the blogs a caller can read take comments from it
+	data, err := s.readVisibleBlog(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	// anonymous comments have no author, so they always wait for a moderator
+	var authorID string
+	if c := callerFromContext(ctx); c != nil {
+		authorID = c.AuthorID
+	}
+	item := &commentItem{
+		BlogID:     oid,
+		AuthorID:   authorID,
+		Content:    content,
+		CreateTime: timeNow(),
+	}
+	if comment.GetParentCommentId() != "" {
+		if err := s.setCommentParent(ctx, item, comment.GetParentCommentId()); err != nil {
+			return nil, err
+		}
+	}
+	if item.State, err = s.initialCommentState(ctx, data, item.AuthorID); err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Internal error: %v", err,
+		)
+	}
+
+	created, err := s.store.CreateComment(ctx, item)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Internal error: %v", err,
+		)
+	}
+
+	return &blogpb.CreateCommentResponse{
+		Comment: commentToPb(created),
+	}, nil
+}
+
+// setCommentParent makes comment a reply to the comment with the given ID, which must be approved
+func (s *server) setCommentParent(ctx context.Context, comment *commentItem, parentID string) error {
+	oid, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse parent comment ID",
+		)
+	}
+	parent, err := s.store.ReadComment(ctx, comment.BlogID, oid)
+	if err != nil {
+		return storeError(err)
+	}
+	if parent.State != blogpb.Comment_APPROVED {
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"Cannot reply to comment %v, it is %v", parentID, parent.State,
+		)
+	}
+	if parent.Depth >= maxCommentDepth {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Replies cannot be nested more than %v levels deep", maxCommentDepth,
+		)
+	}
+	comment.ParentID = parent.ID
+	comment.Depth = parent.Depth + 1
+	return nil
+}
+
+// initialCommentState approves comments from the author of the blog and from authors who already
+// have an approved comment, and holds the others for moderation
+func (s *server) initialCommentState(ctx context.Context, data *blogItem, authorID string) (blogpb.Comment_State, error) {
+	if authorID == "" {
+		return blogpb.Comment_PENDING, nil
+	}
+	if authorID == data.AuthorID {
+		return blogpb.Comment_APPROVED, nil
+	}
+	known, err := s.store.HasApprovedComment(ctx, authorID)
+	if err != nil {
+		return blogpb.Comment_STATE_UNSPECIFIED, err
+	}
+	if known {
+		return blogpb.Comment_APPROVED, nil
+	}
+	return blogpb.Comment_PENDING, nil
+}
+
+func (s *server) ListComments(req *blogpb.ListCommentsRequest, stream blogpb.BlogService_ListCommentsServer) error {
+	fmt.Println("List comments request")
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse ID",
+		)
+	}
+	if req.GetPageSize() < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Page size cannot be negative: %v", req.GetPageSize(),
+		)
+	}
+	query := "comments:" + oid.Hex()
+	token, err := decodePageToken(req.GetPageToken(), query)
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse page token: %v", err,
+		)
+	}
+	size := pageSize(req.GetPageSize())
+
+	ctx := stream.Context()
+	if _, err := s.readVisibleBlog(ctx, oid); err != nil {
+		return storeError(err)
+	}
+
+	// we ask for one more comment than needed to know if there is a next page
+	comments, err := s.store.ListComments(ctx, commentQuery{
+		BlogID: oid,
+		State:  blogpb.Comment_APPROVED,
+		After:  token.LastID,
+		Limit:  size + 1,
+	})
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			"Unknown internal error: %v", err,
+		)
+	}
+	hasMore := len(comments) > size
+	if hasMore {
+		comments = comments[:size]
+	}
+
+	for i, comment := range comments {
+		res := &blogpb.ListCommentsResponse{Comment: commentToPb(comment)}
+		if hasMore && i == len(comments)-1 {
+			res.NextPageToken = pageToken{LastID: comment.ID, Query: query}.encode()
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) DeleteComment(ctx context.Context, req *blogpb.DeleteCommentRequest) (*blogpb.DeleteCommentResponse, error) {
+	fmt.Println("Delete comment request")
+	blogID, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse blog ID",
+		)
+	}
+	commentID, err := primitive.ObjectIDFromHex(req.GetCommentId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse comment ID",
+		)
+	}
+
+	data, err := s.readLiveBlog(ctx, blogID)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	c, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// comments can be deleted by whoever wrote them, and by the author of the blog
+	if !c.canManage(data.AuthorID) {
+		comment, err := s.store.ReadComment(ctx, blogID, commentID)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		if comment.AuthorID != c.AuthorID {
+			return nil, status.Errorf(
+				codes.PermissionDenied,
+				"Only the author of the comment or of the blog, or an admin can delete a comment",
+			)
+		}
+	}
+	if err := s.store.DeleteComment(ctx, blogID, commentID); err != nil {
+		return nil, storeError(err)
+	}
+
+	return &blogpb.DeleteCommentResponse{CommentId: req.GetCommentId()}, nil
+}
+
+func (s *server) ModerateComment(ctx context.Context, req *blogpb.ModerateCommentRequest) (*blogpb.ModerateCommentResponse, error) {
+	fmt.Println("Moderate comment request")
+	blogID, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse blog ID",
+		)
+	}
+	commentID, err := primitive.ObjectIDFromHex(req.GetCommentId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse comment ID",
+		)
+	}
+	if req.GetState() != blogpb.Comment_APPROVED && req.GetState() != blogpb.Comment_REJECTED {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Comments can only be moderated to APPROVED or REJECTED, got %v", req.GetState(),
+		)
+	}
+
+	data, err := s.readLiveBlog(ctx, blogID)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	// the author of a blog moderates its comments
+	if _, err := requireAuthor(ctx, data.AuthorID); err != nil {
+		return nil, err
+	}
+	comment, err := s.store.SetCommentState(ctx, blogID, commentID, req.GetState())
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &blogpb.ModerateCommentResponse{
+		Comment: commentToPb(comment),
+	}, nil
+}
+
+func (s *server) ListPendingComments(req *blogpb.ListPendingCommentsRequest, stream blogpb.BlogService_ListPendingCommentsServer) error {
+	fmt.Println("List pending comments request")
+	var blogID primitive.ObjectID
+	if req.GetBlogId() != "" {
+		oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+		if err != nil {
+			return status.Errorf(
+				codes.InvalidArgument,
+				"Cannot parse ID",
+			)
+		}
+		blogID = oid
+	}
+	if req.GetPageSize() < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Page size cannot be negative: %v", req.GetPageSize(),
+		)
+	}
+	query := "pending-comments:" + req.GetBlogId()
+	token, err := decodePageToken(req.GetPageToken(), query)
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse page token: %v", err,
+		)
+	}
+	size := pageSize(req.GetPageSize())
+
+	ctx := stream.Context()
+	c, err := requireCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if blogID.IsZero() {
+		if !c.Admin {
+			return status.Errorf(
+				codes.PermissionDenied,
+				"Only an admin can list the pending comments of every blog",
+			)
+		}
+	} else {
+		data, err := s.readLiveBlog(ctx, blogID)
+		if err != nil {
+			return storeError(err)
+		}
+		if !c.canManage(data.AuthorID) {
+			return status.Errorf(
+				codes.PermissionDenied,
+				"Only the author or an admin can do this",
+			)
+		}
+	}
+
+	// we ask for one more comment than needed to know if there is a next page
+	comments, err := s.store.ListComments(ctx, commentQuery{
+		BlogID: blogID,
+		State:  blogpb.Comment_PENDING,
+		After:  token.LastID,
+		Limit:  size + 1,
+	})
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			"Unknown internal error: %v", err,
+		)
+	}
+	hasMore := len(comments) > size
+	if hasMore {
+		comments = comments[:size]
+	}
+
+	for i, comment := range comments {
+		res := &blogpb.ListPendingCommentsResponse{Comment: commentToPb(comment)}
+		if hasMore && i == len(comments)-1 {
+			res.NextPageToken = pageToken{LastID: comment.ID, Query: query}.encode()
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
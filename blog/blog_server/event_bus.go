@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultEventBacklog is how many past events an eventBus keeps for resuming watchers
+const defaultEventBacklog = 10000
+
+// eventBus is an in-process publish/subscribe log of blog events.
+// Every event gets a sequence number, used with the epoch of the bus as its resume token,
+// and the last events are kept so a watcher that reconnects quickly does not miss any.
+type eventBus struct {
+	// epoch tells the buses of different processes apart, their sequence numbers all start at 1
+	epoch string
+	mu    sync.Mutex
+	// backlog holds the most recent events, the last one having sequence number last
+	backlog []blogEvent
+	last    int64
+	max     int
+	// published is closed, then replaced, every time an event is published
+	published chan struct{}
+}
+
+func newEventBus(backlog int) *eventBus {
+	return &eventBus{
+		epoch:     primitive.NewObjectID().Hex(),
+		max:       backlog,
+		published: make(chan struct{}),
+	}
+}
+
+// publish appends an event to the log and wakes up the watchers
+func (b *eventBus) publish(typ blogpb.BlogEvent_Type, data *blogItem) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.last++
+	copied := *data
+	b.backlog = append(b.backlog, blogEvent{
+		Type:        typ,
+		Blog:        &copied,
+		ResumeToken: b.epoch + "." + strconv.FormatInt(b.last, 10),
+	})
+	if len(b.backlog) > b.max {
+		b.backlog = b.backlog[len(b.backlog)-b.max:]
+	}
+
+	close(b.published)
+	b.published = make(chan struct{})
+}
+
+// watch calls fn for every event published after resumeToken, or from now on when it is empty,
+// until ctx is done or fn returns an error
+func (b *eventBus) watch(ctx context.Context, resumeToken string, fn func(blogEvent) error) error {
+	b.mu.Lock()
+	after := b.last
+	b.mu.Unlock()
+	if resumeToken != "" {
+		epoch, seq, err := parseResumeToken(resumeToken)
+		if err != nil {
+			return err
+		}
+		// the events of another process, or of this one before a restart, are gone
+		if epoch != b.epoch {
+			return errResumeTokenExpired
+		}
+		if seq > after {
+			return errInvalidResumeToken
+		}
+		after = seq
+	}
+
+	for {
+		b.mu.Lock()
+		first := b.last - int64(len(b.backlog)) + 1
+		if after+1 < first {
+			b.mu.Unlock()
+			return errResumeTokenExpired
+		}
+		pending := append([]blogEvent(nil), b.backlog[after+1-first:]...)
+		published := b.published
+		b.mu.Unlock()
+
+		// events are delivered without holding the lock so a slow watcher does not block writers
+		for _, ev := range pending {
+			if err := fn(ev); err != nil {
+				return err
+			}
+			after++
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-published:
+		}
+	}
+}
+
+// parseResumeToken splits a resume token into the epoch of the bus that made it and a sequence number
+func parseResumeToken(token string) (string, int64, error) {
+	i := strings.LastIndex(token, ".")
+	if i <= 0 {
+		return "", 0, errInvalidResumeToken
+	}
+	seq, err := strconv.ParseInt(token[i+1:], 10, 64)
+	if err != nil || seq < 0 {
+		return "", 0, errInvalidResumeToken
+	}
+	return token[:i], seq, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultRequestIDWindow is how long CreateBlog remembers request IDs by default
+	defaultRequestIDWindow = 24 * time.Hour
+	// maxRequestIDLength leaves room for UUIDs and other common ID formats
+	maxRequestIDLength = 128
+	// maxRequestIDAttempts bounds the retries when the blog holding a request ID goes away while it is looked up
+	maxRequestIDAttempts = 3
+)
+
+// requestKey identifies a CreateBlog request ID. Request IDs are chosen by clients, so they are scoped
+// to the caller who sent them: two callers picking the same ID never see each other's blogs.
+type requestKey struct {
+	CallerID  string `bson:"caller_id"`
+	RequestID string `bson:"request_id"`
+}
+
+// blogRequest is the CreateBlog request ID a blog was created with.
+// It is stored in the blog itself, so the blog and its request ID are written at once.
+type blogRequest struct {
+	requestKey `bson:",inline"`
+	// ExpireTime is when the request ID can be used again for a new blog
+	ExpireTime time.Time `bson:"expire_time"`
+}
+
+// createWithRequestID creates a blog holding a CreateBlog request ID of the caller.
+// When the caller already used the request ID, it returns the blog created by that first request instead.
+func (s *server) createWithRequestID(ctx context.Context, c *caller, requestID string, data *blogItem) (*blogItem, error) {
+	if len(requestID) > maxRequestIDLength {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Request ID cannot be longer than %v characters", maxRequestIDLength,
+		)
+	}
+	window := s.requestIDWindow
+	if window == 0 {
+		window = defaultRequestIDWindow
+	}
+	key := requestKey{CallerID: c.AuthorID, RequestID: requestID}
+	data.Request = &blogRequest{requestKey: key, ExpireTime: data.CreateTime.Add(window)}
+
+	for attempt := 0; attempt < maxRequestIDAttempts; attempt++ {
+		created, err := s.store.Create(ctx, data)
+		if err == nil {
+			return created, nil
+		}
+		if err != errRequestIDTaken {
+			return nil, storeError(err)
+		}
+		existing, err := s.store.ReadByRequestID(ctx, key, data.CreateTime)
+		if err == errBlogNotFound {
+			// the request ID expired or its blog was purged in the meantime, it is free again
+			continue
+		}
+		if err != nil {
+			return nil, storeError(err)
+		}
+		// the blog may have changed hands or gone to the trash since, a retry only gets it back while it is still ours
+		if existing.DeleteTime != nil {
+			return nil, status.Errorf(
+				codes.FailedPrecondition,
+				"The blog created with request ID %v was deleted, use a new request ID", requestID,
+			)
+		}
+		if !c.canManage(existing.AuthorID) {
+			return nil, status.Errorf(
+				codes.AlreadyExists,
+				"Request ID %v was already used for a blog you can no longer access, use a new request ID", requestID,
+			)
+		}
+		return existing, nil
+	}
+	return nil, status.Errorf(
+		codes.Aborted,
+		"Request ID %v is changing hands, retry later", requestID,
+	)
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// blogFilter is the parsed form of ListBlogRequest.filter.
+// Empty fields do not filter anything.
+type blogFilter struct {
+	AuthorID    string
+	Title       string
+	TitlePrefix string
+	// Deleted selects blogs in the trash when true, and blogs out of it when false
+	Deleted *bool
+	// Tags selects blogs with all of these tags, or any of them when AnyTag is set
+	Tags   []string
+	AnyTag bool
+	// State selects blogs in this state unless it is STATE_UNSPECIFIED
+	State blogpb.Blog_State
+}
+
+func (f blogFilter) matches(data *blogItem) bool {
+	if f.AuthorID != "" && data.AuthorID != f.AuthorID {
+		return false
+	}
+	if f.Title != "" && data.Title != f.Title {
+		return false
+	}
+	if f.TitlePrefix != "" && !strings.HasPrefix(data.Title, f.TitlePrefix) {
+		return false
+	}
+	if f.Deleted != nil && *f.Deleted != (data.DeleteTime != nil) {
+		return false
+	}
+	if f.State != blogpb.Blog_STATE_UNSPECIFIED && data.State != f.State {
+		return false
+	}
+	if len(f.Tags) > 0 {
+		found := 0
+		for _, tag := range f.Tags {
+			for _, t := range data.Tags {
+				if t == tag {
+					found++
+					break
+				}
+			}
+		}
+		if found == 0 || (!f.AnyTag && found < len(f.Tags)) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a canonical form of the filter, used to tie page tokens to it
+func (f blogFilter) String() string {
+	deleted := "any"
+	if f.Deleted != nil {
+		deleted = strconv.FormatBool(*f.Deleted)
+	}
+	return fmt.Sprintf("author_id=%q title=%q title_prefix=%q deleted=%v tags=%q any_tag=%v state=%v",
+		f.AuthorID, f.Title, f.TitlePrefix, deleted, f.Tags, f.AnyTag, f.State)
+}
+
+// parseFilter parses a filter expression such as
+//
+//	author_id = "Stephane" AND title = "My First*"
+//
+// Values can be quoted or bare words, and a trailing * on a title turns it into a prefix match,
+// while \* matches a literal *.
+func parseFilter(expr string) (blogFilter, error) {
+	var f blogFilter
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return f, err
+	}
+	for i := 0; i < len(tokens); {
+		if i > 0 {
+			if tokens[i] != "AND" {
+				return f, fmt.Errorf("expected AND, got %q", tokens[i])
+			}
+			i++
+		}
+		if i+3 > len(tokens) {
+			return f, fmt.Errorf("incomplete comparison at the end of the filter")
+		}
+		field, op, raw := tokens[i], tokens[i+1], tokens[i+2]
+		value := unquote(raw)
+		i += 3
+		if op != "=" {
+			return f, fmt.Errorf("unsupported operator %q, only = is supported", op)
+		}
+		switch field {
+		case "author_id":
+			f.AuthorID = value
+		case "title":
+			title, prefix := titlePattern(raw)
+			switch {
+			case prefix && title == "":
+				return f, fmt.Errorf("title prefix cannot be empty, leave the title out to match every title")
+			case prefix:
+				f.TitlePrefix = title
+			default:
+				f.Title = title
+			}
+		case "deleted":
+			deleted, err := strconv.ParseBool(value)
+			if err != nil {
+				return f, fmt.Errorf("deleted must be true or false, got %q", value)
+			}
+			f.Deleted = &deleted
+		case "state":
+			state := blogpb.Blog_State(blogpb.Blog_State_value[strings.ToUpper(value)])
+			if state == blogpb.Blog_STATE_UNSPECIFIED {
+				return f, fmt.Errorf("state must be DRAFT, SCHEDULED, PUBLISHED or ARCHIVED, got %q", value)
+			}
+			f.State = state
+		default:
+			return f, fmt.Errorf("unsupported filter field %q", field)
+		}
+	}
+	return f, nil
+}
+
+// tokenizeFilter splits a filter into words, = signs and quoted strings (kept with their quotes)
+func tokenizeFilter(expr string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '=':
+			tokens = append(tokens, "=")
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", i)
+			}
+			tokens = append(tokens, string(runes[i:end+1]))
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '=' && runes[i] != '"' {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		}
+	}
+	return tokens, nil
+}
+
+// titlePattern reads the value of a title comparison, telling if it ends with the * of a prefix match.
+// A \* stands for a literal *, so titles ending with a * can be matched exactly.
+func titlePattern(token string) (title string, prefix bool) {
+	quoted := strings.HasPrefix(token, `"`)
+	if quoted {
+		token = token[1 : len(token)-1]
+	}
+	runes := []rune(token)
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		escaped := i+1 < len(runes) && (runes[i+1] == '*' || quoted && (runes[i+1] == '"' || runes[i+1] == '\\'))
+		switch {
+		case r == '\\' && escaped:
+			i++
+			b.WriteRune(runes[i])
+		case r == '*' && i == len(runes)-1:
+			prefix = true
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), prefix
+}
+
+func unquote(token string) string {
+	if !strings.HasPrefix(token, `"`) {
+		return token
+	}
+	token = token[1 : len(token)-1]
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(token)
+}
+
+// sortKey describes a field ListBlog can be ordered by
+type sortKey struct {
+	// field is the name of the field in MongoDB
+	field string
+	// value returns the key of a blog as stored in page tokens,
+	// encoded so that comparing two values as strings matches the field order
+	value func(data *blogItem) string
+	// parse converts a value from a page token back to what is stored in MongoDB,
+	// nil when the blog does not have the field
+	parse func(value string) (interface{}, error)
+}
+
+// sortKeys are the fields accepted by ListBlogRequest.order_by.
+// Ties are always broken by ID so every ordering is total.
+var sortKeys = map[string]sortKey{
+	"id": {
+		field: "_id",
+	},
+	"title": {
+		field: "title",
+		value: func(data *blogItem) string { return data.Title },
+		parse: func(value string) (interface{}, error) { return value, nil },
+	},
+	"create_time": {
+		field: "create_time",
+		value: func(data *blogItem) string { return formatSortTime(data.CreateTime) },
+		parse: parseSortTime,
+	},
+	"update_time": {
+		field: "update_time",
+		value: func(data *blogItem) string { return formatSortTime(data.UpdateTime) },
+		parse: parseSortTime,
+	},
+}
+
+// sortTimeLayout has a fixed width so that formatted times sort like the times themselves
+const sortTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+func formatSortTime(t time.Time) string {
+	return t.UTC().Format(sortTimeLayout)
+}
+
+// parseSortTime returns nil for the zero time, which stands for blogs written before the field existed
+func parseSortTime(value string) (interface{}, error) {
+	t, err := time.Parse(sortTimeLayout, value)
+	if err != nil || t.IsZero() {
+		return nil, err
+	}
+	return t, nil
+}
+
+// blogOrder is the parsed form of ListBlogRequest.order_by
+type blogOrder struct {
+	Key  string
+	Desc bool
+}
+
+func (o blogOrder) sortKey() sortKey {
+	return sortKeys[o.Key]
+}
+
+func (o blogOrder) String() string {
+	if o.Desc {
+		return o.Key + " desc"
+	}
+	return o.Key
+}
+
+// parseOrderBy parses an order_by such as "title desc", defaulting to creation order
+func parseOrderBy(orderBy string) (blogOrder, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return blogOrder{Key: "id"}, nil
+	}
+	if len(parts) > 2 {
+		return blogOrder{}, fmt.Errorf("order_by only supports a single field")
+	}
+	order := blogOrder{Key: parts[0]}
+	if _, ok := sortKeys[order.Key]; !ok {
+		return blogOrder{}, fmt.Errorf("unsupported order_by field %q", order.Key)
+	}
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return blogOrder{}, fmt.Errorf("unsupported order_by direction %q", parts[1])
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+func TestParseFilter(t *testing.T) {
+	yes := true
+	tests := []struct {
+		expr    string
+		want    blogFilter
+		wantErr bool
+	}{
+		{``, blogFilter{}, false},
+		{`author_id = "Stephane"`, blogFilter{AuthorID: "Stephane"}, false},
+		{`author_id = Stephane AND title = "My First*"`, blogFilter{AuthorID: "Stephane", TitlePrefix: "My First"}, false},
+		{`title = "q\"\\"`, blogFilter{Title: `q"\`}, false},
+		{`title = "Stars\*"`, blogFilter{Title: "Stars*"}, false},
+		{`title = Stars\*`, blogFilter{Title: "Stars*"}, false},
+		{`title = "a\*b*"`, blogFilter{TitlePrefix: "a*b"}, false},
+		{`title = "q\"\\*"`, blogFilter{TitlePrefix: `q"\`}, false},
+		{`title = "\\\*"`, blogFilter{Title: `\*`}, false},
+		{`title = "x\y"`, blogFilter{Title: `x\y`}, false},
+		{`deleted = true`, blogFilter{Deleted: &yes}, false},
+		{`state = draft`, blogFilter{State: blogpb.Blog_DRAFT}, false},
+		{`title = "*"`, blogFilter{}, true},
+		{`title = *`, blogFilter{}, true},
+		{`deleted = maybe`, blogFilter{}, true},
+		{`state = gone`, blogFilter{}, true},
+		{`content = "x"`, blogFilter{}, true},
+		{`author_id > 3`, blogFilter{}, true},
+		{`author_id = "x`, blogFilter{}, true},
+		{`author_id = x OR title = y`, blogFilter{}, true},
+		{`author_id =`, blogFilter{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseFilter(tt.expr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseFilter(%q) error = %v, want error %v", tt.expr, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFilter(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    blogOrder
+		wantErr bool
+	}{
+		{"", blogOrder{Key: "id"}, false},
+		{"title", blogOrder{Key: "title"}, false},
+		{"title asc", blogOrder{Key: "title"}, false},
+		{"update_time DESC", blogOrder{Key: "update_time", Desc: true}, false},
+		{"content", blogOrder{}, true},
+		{"title sideways", blogOrder{}, true},
+		{"title desc id", blogOrder{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseOrderBy(tt.orderBy)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseOrderBy(%q) = %v, %v, want %v, error %v", tt.orderBy, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// memoryStore is a BlogStore that keeps every blog in memory.
+// It is meant for local development and tests where MongoDB is not available.
+type memoryStore struct {
+	mu    sync.RWMutex
+	blogs map[primitive.ObjectID]*blogItem
+	// order keeps the IDs in insertion order
+	order []primitive.ObjectID
+	// revisions of each blog, from the oldest to the newest
+	revisions map[primitive.ObjectID][]*revisionItem
+	// comments of each blog, from the oldest to the newest
+	comments map[primitive.ObjectID][]*commentItem
+	// events is fed while holding mu so events come in the same order as the writes
+	events *eventBus
+	// requests maps CreateBlog request IDs to the blogs holding them, expired or not
+	requests map[requestKey]primitive.ObjectID
+	// search indexes every blog, deleted ones are filtered out when searching
+	search *searchIndex
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		blogs:     make(map[primitive.ObjectID]*blogItem),
+		revisions: make(map[primitive.ObjectID][]*revisionItem),
+		comments:  make(map[primitive.ObjectID][]*commentItem),
+		events:    newEventBus(defaultEventBacklog),
+		requests:  make(map[requestKey]primitive.ObjectID),
+		search:    newSearchIndex(),
+	}
+}
+
+func (s *memoryStore) Create(_ context.Context, data *blogItem) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created := *data
+	if created.Request != nil {
+		// like the unique index of mongoStore, an expired request ID is taken back from the blog holding it
+		if holder, ok := s.requests[created.Request.requestKey]; ok {
+			if s.blogs[holder].Request.ExpireTime.After(created.CreateTime) {
+				return nil, errRequestIDTaken
+			}
+			released := *s.blogs[holder]
+			released.Request = nil
+			s.blogs[holder] = &released
+		}
+	}
+	if created.ID.IsZero() {
+		created.ID = primitive.NewObjectID()
+	}
+	created.Version = 1
+	s.blogs[created.ID] = &created
+	if created.Request != nil {
+		s.requests[created.Request.requestKey] = created.ID
+	}
+	s.order = append(s.order, created.ID)
+	s.search.add(&created)
+	s.events.publish(eventTypeOf(&created, true), &created)
+
+	res := created
+	return &res, nil
+}
+
+func (s *memoryStore) Read(_ context.Context, id primitive.ObjectID) (*blogItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.blogs[id]
+	if !ok {
+		return nil, errBlogNotFound
+	}
+	res := *data
+	return &res, nil
+}
+
+func (s *memoryStore) Update(_ context.Context, data *blogItem) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.blogs[data.ID]
+	if !ok {
+		return nil, errBlogNotFound
+	}
+	if current.Version != data.Version {
+		return nil, errVersionMismatch
+	}
+	updated := *data
+	updated.Version++
+	// the request ID may have been taken back since data was read
+	updated.Request = current.Request
+	s.blogs[data.ID] = &updated
+	s.search.add(&updated)
+	s.events.publish(eventTypeOf(&updated, false), &updated)
+
+	res := updated
+	return &res, nil
+}
+
+func (s *memoryStore) PurgeDeleted(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	kept := s.order[:0]
+	for _, id := range s.order {
+		data := s.blogs[id]
+		if data.DeleteTime != nil && data.DeleteTime.Before(before) {
+			delete(s.blogs, id)
+			delete(s.revisions, id)
+			delete(s.comments, id)
+			if data.Request != nil && s.requests[data.Request.requestKey] == id {
+				delete(s.requests, data.Request.requestKey)
+			}
+			s.search.remove(id)
+			purged++
+			continue
+		}
+		kept = append(kept, id)
+	}
+	s.order = kept
+	return purged, nil
+}
+
+func (s *memoryStore) List(_ context.Context, q listQuery) ([]*blogItem, error) {
+	s.mu.RLock()
+	var matches []*blogItem
+	for _, id := range s.order {
+		if data := s.blogs[id]; q.Filter.matches(data) {
+			copied := *data
+			matches = append(matches, &copied)
+		}
+	}
+	s.mu.RUnlock()
+
+	key := q.Order.sortKey()
+	sort.SliceStable(matches, func(i, j int) bool {
+		c := compareCursors(cursorOf(matches[i], key), cursorOf(matches[j], key))
+		if q.Order.Desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	start := 0
+	if q.After != nil {
+		start = sort.Search(len(matches), func(i int) bool {
+			c := compareCursors(cursorOf(matches[i], key), *q.After)
+			if q.Order.Desc {
+				return c < 0
+			}
+			return c > 0
+		})
+	}
+	matches = matches[start:]
+	if len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+	return matches, nil
+}
+
+// compareCursors orders cursors by sort key then ID
+func compareCursors(a, b listCursor) int {
+	if a.Key != b.Key {
+		return strings.Compare(a.Key, b.Key)
+	}
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+func (s *memoryStore) AddRevision(_ context.Context, rev *revisionItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revs := s.revisions[rev.BlogID]
+	i := sort.Search(len(revs), func(i int) bool { return revs[i].Version >= rev.Version })
+	if i < len(revs) && revs[i].Version == rev.Version {
+		return nil
+	}
+	added := *rev
+	added.ID = primitive.NewObjectID()
+	revs = append(revs, nil)
+	copy(revs[i+1:], revs[i:])
+	revs[i] = &added
+	s.revisions[rev.BlogID] = revs
+	return nil
+}
+
+func (s *memoryStore) ListRevisions(_ context.Context, blogID primitive.ObjectID, before int64, limit int, publishedOnly bool) ([]*revisionItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var res []*revisionItem
+	revs := s.revisions[blogID]
+	for i := len(revs) - 1; i >= 0 && len(res) < limit; i-- {
+		if before != 0 && revs[i].Version >= before || publishedOnly && revs[i].State != blogpb.Blog_PUBLISHED {
+			continue
+		}
+		rev := *revs[i]
+		res = append(res, &rev)
+	}
+	return res, nil
+}
+
+func (s *memoryStore) ReadRevision(_ context.Context, blogID primitive.ObjectID, version int64) (*revisionItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, rev := range s.revisions[blogID] {
+		if rev.Version == version {
+			res := *rev
+			return &res, nil
+		}
+	}
+	return nil, errRevisionNotFound
+}
+
+func (s *memoryStore) CreateComment(_ context.Context, comment *commentItem) (*commentItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// ObjectIDs grow over time, so appending keeps the comments sorted by ID
+	created := *comment
+	created.ID = primitive.NewObjectID()
+	s.comments[created.BlogID] = append(s.comments[created.BlogID], &created)
+
+	res := created
+	return &res, nil
+}
+
+func (s *memoryStore) ReadComment(_ context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID) (*commentItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, comment := range s.comments[blogID] {
+		if comment.ID == commentID {
+			res := *comment
+			return &res, nil
+		}
+	}
+	return nil, errCommentNotFound
+}
+
+func (s *memoryStore) ListComments(_ context.Context, q commentQuery) ([]*commentItem, error) {
+	s.mu.RLock()
+	var matches []*commentItem
+	for blogID, comments := range s.comments {
+		if !q.BlogID.IsZero() && blogID != q.BlogID {
+			continue
+		}
+		if blog, ok := s.blogs[blogID]; q.BlogID.IsZero() && ok && blog.DeleteTime != nil {
+			continue
+		}
+		for _, comment := range comments {
+			if comment.State == q.State && bytes.Compare(comment.ID[:], q.After[:]) > 0 {
+				copied := *comment
+				matches = append(matches, &copied)
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		return bytes.Compare(matches[i].ID[:], matches[j].ID[:]) < 0
+	})
+	if len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+	return matches, nil
+}
+
+func (s *memoryStore) SetCommentState(_ context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID, state blogpb.Comment_State) (*commentItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, comment := range s.comments[blogID] {
+		if comment.ID == commentID {
+			comment.State = state
+			res := *comment
+			return &res, nil
+		}
+	}
+	return nil, errCommentNotFound
+}
+
+func (s *memoryStore) HasApprovedComment(_ context.Context, authorID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, comments := range s.comments {
+		for _, comment := range comments {
+			if comment.AuthorID == authorID && comment.State == blogpb.Comment_APPROVED {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (s *memoryStore) DeleteComment(_ context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// replies always come after their parent, so a single pass finds the whole thread
+	deleted := map[primitive.ObjectID]bool{commentID: true}
+	comments := s.comments[blogID]
+	kept := make([]*commentItem, 0, len(comments))
+	found := false
+	for _, comment := range comments {
+		if comment.ID == commentID {
+			found = true
+			continue
+		}
+		if found && deleted[comment.ParentID] {
+			deleted[comment.ID] = true
+			continue
+		}
+		kept = append(kept, comment)
+	}
+	if !found {
+		return errCommentNotFound
+	}
+	s.comments[blogID] = kept
+	return nil
+}
+
+func (s *memoryStore) ReadByRequestID(_ context.Context, key requestKey, now time.Time) (*blogItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.requests[key]
+	if !ok || !s.blogs[id].Request.ExpireTime.After(now) {
+		return nil, errBlogNotFound
+	}
+	res := *s.blogs[id]
+	return &res, nil
+}
+
+func (s *memoryStore) ListScheduled(_ context.Context, before time.Time, limit int) ([]*blogItem, error) {
+	s.mu.RLock()
+	var due []*blogItem
+	for _, data := range s.blogs {
+		if data.DeleteTime == nil && data.State == blogpb.Blog_SCHEDULED && !data.PublishTime.After(before) {
+			copied := *data
+			due = append(due, &copied)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].PublishTime.Equal(*due[j].PublishTime) {
+			return due[i].PublishTime.Before(*due[j].PublishTime)
+		}
+		return bytes.Compare(due[i].ID[:], due[j].ID[:]) < 0
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (s *memoryStore) CountTags(_ context.Context) ([]tagCount, error) {
+	s.mu.RLock()
+	counts := make(map[string]int64)
+	for _, data := range s.blogs {
+		if data.DeleteTime != nil || data.State != blogpb.Blog_PUBLISHED {
+			continue
+		}
+		for _, tag := range data.Tags {
+			counts[tag]++
+		}
+	}
+	s.mu.RUnlock()
+
+	res := make([]tagCount, 0, len(counts))
+	for tag, count := range counts {
+		res = append(res, tagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Tag < res[j].Tag
+	})
+	return res, nil
+}
+
+func (s *memoryStore) Search(_ context.Context, query string, offset, limit int) ([]*searchHit, error) {
+	s.mu.RLock()
+	q := parseSearchQuery(query)
+	var hits []*searchHit
+	for id, score := range s.search.search(q.terms) {
+		data := s.blogs[id]
+		if data.DeleteTime != nil || data.State != blogpb.Blog_PUBLISHED || !q.matches(data) {
+			continue
+		}
+		copied := *data
+		hits = append(hits, &searchHit{Blog: &copied, Score: score})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return bytes.Compare(hits[i].Blog.ID[:], hits[j].Blog.ID[:]) < 0
+	})
+	if offset >= len(hits) {
+		return nil, nil
+	}
+	hits = hits[offset:]
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func (s *memoryStore) Watch(ctx context.Context, resumeToken string, fn func(blogEvent) error) error {
+	return s.events.watch(ctx, resumeToken, fn)
+}
+
+func (s *memoryStore) Close(_ context.Context) error {
+	return nil
+}
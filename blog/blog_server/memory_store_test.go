@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+func TestMemoryStoreRequestIDs(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	now := timeNow()
+	key := requestKey{CallerID: "alice", RequestID: "abc"}
+	create := func(title string, at time.Time) (*blogItem, error) {
+		return store.Create(ctx, &blogItem{
+			AuthorID:   "alice",
+			Title:      title,
+			CreateTime: at,
+			UpdateTime: at,
+			Request:    &blogRequest{requestKey: key, ExpireTime: at.Add(time.Hour)},
+		})
+	}
+
+	first, err := create("first", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := create("retry", now.Add(time.Minute)); err != errRequestIDTaken {
+		t.Fatalf("Create() with a held request ID = %v, want errRequestIDTaken", err)
+	}
+	if got, err := store.ReadByRequestID(ctx, key, now.Add(time.Minute)); err != nil || got.ID != first.ID {
+		t.Fatalf("ReadByRequestID() = %v, %v, want blog %v", got, err, first.ID)
+	}
+	if _, err := store.ReadByRequestID(ctx, requestKey{CallerID: "bob", RequestID: "abc"}, now); err != errBlogNotFound {
+		t.Errorf("ReadByRequestID() of another caller = %v, want errBlogNotFound", err)
+	}
+
+	// an update read before the request ID expires does not bring it back once it is taken over
+	stale, err := store.Read(ctx, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := now.Add(2 * time.Hour)
+	if _, err := store.ReadByRequestID(ctx, key, later); err != errBlogNotFound {
+		t.Fatalf("ReadByRequestID() after the window = %v, want errBlogNotFound", err)
+	}
+	second, err := create("second", later)
+	if err != nil {
+		t.Fatalf("Create() with an expired request ID = %v", err)
+	}
+	stale.Title = "edited"
+	if _, err := store.Update(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.ReadByRequestID(ctx, key, later); err != nil || got.ID != second.ID {
+		t.Fatalf("ReadByRequestID() = %v, %v, want blog %v", got, err, second.ID)
+	}
+	if got, err := store.Read(ctx, first.ID); err != nil || got.Request != nil {
+		t.Errorf("Read(first blog) = %v, %v, want it without a request ID", got, err)
+	}
+
+	// purging the second blog frees the request ID
+	deleted := *second
+	deleteTime := later
+	deleted.DeleteTime = &deleteTime
+	if _, err := store.Update(ctx, &deleted); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := store.PurgeDeleted(ctx, later.Add(time.Second)); n != 1 || err != nil {
+		t.Fatalf("PurgeDeleted() = %v, %v, want 1", n, err)
+	}
+	if _, err := store.ReadByRequestID(ctx, key, later); err != errBlogNotFound {
+		t.Errorf("ReadByRequestID() after a purge = %v, want errBlogNotFound", err)
+	}
+	if _, err := create("third", later); err != nil {
+		t.Errorf("Create() after a purge = %v", err)
+	}
+}
+
+func TestMemoryStoreComments(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	blogID := primitive.NewObjectID()
+	otherID := primitive.NewObjectID()
+	add := func(blogID primitive.ObjectID, parent *commentItem, content string, state blogpb.Comment_State) *commentItem {
+		item := &commentItem{BlogID: blogID, AuthorID: "alice", Content: content, State: state}
+		if parent != nil {
+			item.ParentID = parent.ID
+			item.Depth = parent.Depth + 1
+		}
+		created, err := store.CreateComment(ctx, item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return created
+	}
+	root := add(blogID, nil, "root", blogpb.Comment_APPROVED)
+	reply := add(blogID, root, "reply", blogpb.Comment_APPROVED)
+	add(blogID, reply, "nested", blogpb.Comment_PENDING)
+	add(blogID, nil, "held", blogpb.Comment_PENDING)
+	add(otherID, nil, "elsewhere", blogpb.Comment_PENDING)
+	sibling := add(blogID, nil, "sibling", blogpb.Comment_APPROVED)
+
+	contents := func(q commentQuery) []string {
+		comments, err := store.ListComments(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var res []string
+		for _, comment := range comments {
+			res = append(res, comment.Content)
+		}
+		return res
+	}
+	lists := []struct {
+		name string
+		q    commentQuery
+		want []string
+	}{
+		{"approved of a blog", commentQuery{BlogID: blogID, State: blogpb.Comment_APPROVED, Limit: 10}, []string{"root", "reply", "sibling"}},
+		{"pending of a blog", commentQuery{BlogID: blogID, State: blogpb.Comment_PENDING, Limit: 10}, []string{"nested", "held"}},
+		{"pending of every blog", commentQuery{State: blogpb.Comment_PENDING, Limit: 10}, []string{"nested", "held", "elsewhere"}},
+		{"after a comment", commentQuery{BlogID: blogID, State: blogpb.Comment_APPROVED, After: root.ID, Limit: 10}, []string{"reply", "sibling"}},
+		{"limit", commentQuery{State: blogpb.Comment_PENDING, Limit: 2}, []string{"nested", "held"}},
+		{"rejected", commentQuery{BlogID: blogID, State: blogpb.Comment_REJECTED, Limit: 10}, nil},
+	}
+	for _, tt := range lists {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contents(tt.q); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListComments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := store.ReadComment(ctx, otherID, root.ID); err != errCommentNotFound {
+		t.Errorf("ReadComment() through another blog = %v, want errCommentNotFound", err)
+	}
+	if _, err := store.SetCommentState(ctx, otherID, root.ID, blogpb.Comment_REJECTED); err != errCommentNotFound {
+		t.Errorf("SetCommentState() through another blog = %v, want errCommentNotFound", err)
+	}
+	rejected, err := store.SetCommentState(ctx, blogID, sibling.ID, blogpb.Comment_REJECTED)
+	if err != nil || rejected.State != blogpb.Comment_REJECTED {
+		t.Fatalf("SetCommentState() = %v, %v, want a rejected comment", rejected, err)
+	}
+	if known, err := store.HasApprovedComment(ctx, "alice"); err != nil || !known {
+		t.Errorf("HasApprovedComment(alice) = %v, %v, want true", known, err)
+	}
+	if known, err := store.HasApprovedComment(ctx, "bob"); err != nil || known {
+		t.Errorf("HasApprovedComment(bob) = %v, %v, want false", known, err)
+	}
+
+	// deleting the root takes the replies with it, whatever their state
+	if err := store.DeleteComment(ctx, otherID, root.ID); err != errCommentNotFound {
+		t.Errorf("DeleteComment() through another blog = %v, want errCommentNotFound", err)
+	}
+	if err := store.DeleteComment(ctx, blogID, root.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := contents(commentQuery{State: blogpb.Comment_PENDING, Limit: 10}); !reflect.DeepEqual(got, []string{"held", "elsewhere"}) {
+		t.Errorf("pending comments after deleting a thread = %v, want [held elsewhere]", got)
+	}
+	if err := store.DeleteComment(ctx, blogID, reply.ID); err != errCommentNotFound {
+		t.Errorf("DeleteComment(deleted reply) = %v, want errCommentNotFound", err)
+	}
+	if known, err := store.HasApprovedComment(ctx, "alice"); err != nil || known {
+		t.Errorf("HasApprovedComment(alice) after the deletes = %v, %v, want false", known, err)
+	}
+}
@@ -0,0 +1,699 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+
+	mongobson "go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// mongoStore is a BlogStore backed by MongoDB collections
+type mongoStore struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+	// revisions holds the past versions of the blogs in collection
+	revisions *mongo.Collection
+	// comments holds the comments of the blogs in collection
+	comments *mongo.Collection
+}
+
+func newMongoStore(ctx context.Context, uri string) (*mongoStore, error) {
+	client, err := mongo.NewClient(options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Connect(ctx); err != nil {
+		return nil, err
+	}
+	db := client.Database("mydb")
+	s := &mongoStore{
+		client:     client,
+		collection: db.Collection("blog"),
+		revisions:  db.Collection("blog_revisions"),
+		comments:   db.Collection("blog_comments"),
+	}
+
+	// a blog has at most one revision per version, which also makes AddRevision idempotent
+	_, err = s.revisions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    primitive.D{{Key: "blog_id", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// ListComments pages through the comments of a blog in ID order, and ListPendingComments through the queue
+	_, err = s.comments.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: primitive.D{{Key: "blog_id", Value: 1}, {Key: "state", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: primitive.D{{Key: "state", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: primitive.D{{Key: "blog_id", Value: 1}, {Key: "parent_comment_id", Value: 1}}},
+		{Keys: primitive.D{{Key: "author_id", Value: 1}, {Key: "state", Value: 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// ListBlog filters on tags
+	_, err = s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: primitive.D{{Key: "tags", Value: 1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the scheduler looks for the blogs due to be published
+	_, err = s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: primitive.D{{Key: "state", Value: 1}, {Key: "publish_time", Value: 1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// SearchBlogs relies on a text index, a collection can only have one
+	_, err = s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    primitive.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}},
+		Options: options.Index().SetWeights(bson.M{"title": titleWeight, "content": 1}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// a CreateBlog request ID of a caller is held by one blog at most, making the insert of a retry fail
+	_, err = s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: primitive.D{{Key: "request.caller_id", Value: 1}, {Key: "request.request_id", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"request": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *mongoStore) Create(ctx context.Context, data *blogItem) (*blogItem, error) {
+	created := *data
+	created.Version = 1
+	res, err := s.collection.InsertOne(ctx, &created)
+	if mongo.IsDuplicateKeyError(err) && data.Request != nil {
+		// the unique index also covers expired request IDs, those are taken back from their blog
+		filter := requestFilter(data.Request.requestKey)
+		filter["request.expire_time"] = bson.M{"$lte": data.CreateTime}
+		released, releaseErr := s.collection.UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"request": ""}})
+		if releaseErr != nil {
+			return nil, releaseErr
+		}
+		if released.ModifiedCount == 0 {
+			return nil, errRequestIDTaken
+		}
+		res, err = s.collection.InsertOne(ctx, &created)
+	}
+	if mongo.IsDuplicateKeyError(err) && data.Request != nil {
+		return nil, errRequestIDTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert to OID")
+	}
+	created.ID = oid
+	return &created, nil
+}
+
+func (s *mongoStore) Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	// create an empty struct
+	data := &blogItem{}
+	filter := bson.M{"_id": id}
+
+	res := s.collection.FindOne(ctx, filter)
+	if err := res.Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errBlogNotFound
+		}
+		return nil, err
+	}
+	return normalizeLegacy(data), nil
+}
+
+func (s *mongoStore) Update(ctx context.Context, data *blogItem) (*blogItem, error) {
+	filter := versionFilter(data.ID, data.Version)
+	if data.Request != nil {
+		// the request ID may have been taken back since data was read, the replacement must not restore it
+		filter["request.expire_time"] = data.Request.ExpireTime
+	}
+
+	updated := *data
+	updated.Version++
+	res, err := s.collection.ReplaceOne(ctx, filter, &updated)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, s.conflictError(ctx, data.ID)
+	}
+	return &updated, nil
+}
+
+func (s *mongoStore) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	filter := bson.M{"delete_time": bson.M{"$lt": before}}
+	ids, err := s.collection.Distinct(ctx, "_id", filter)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// revisions and comments go first so a failure never leaves them without their blog
+	if _, err := s.revisions.DeleteMany(ctx, bson.M{"blog_id": bson.M{"$in": ids}}); err != nil {
+		return 0, err
+	}
+	if _, err := s.comments.DeleteMany(ctx, bson.M{"blog_id": bson.M{"$in": ids}}); err != nil {
+		return 0, err
+	}
+	res, err := s.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (s *mongoStore) AddRevision(ctx context.Context, rev *revisionItem) error {
+	filter := bson.M{"blog_id": rev.BlogID, "version": rev.Version}
+	_, err := s.revisions.UpdateOne(ctx, filter, bson.M{"$setOnInsert": rev}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStore) ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int, publishedOnly bool) ([]*revisionItem, error) {
+	filter := bson.M{"blog_id": blogID}
+	if before != 0 {
+		filter["version"] = bson.M{"$lt": before}
+	}
+	if publishedOnly {
+		filter = bson.M{"$and": []bson.M{filter, stateFilter(blogpb.Blog_PUBLISHED)}}
+	}
+	opts := options.Find().
+		SetSort(primitive.D{{Key: "version", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cur, err := s.revisions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx) // Should handle err
+
+	var revs []*revisionItem
+	for cur.Next(ctx) {
+		rev := &revisionItem{}
+		if err := cur.Decode(rev); err != nil {
+			return nil, fmt.Errorf("error while decoding data from MongoDB: %v", err)
+		}
+		revs = append(revs, normalizeLegacyRevision(rev))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+func (s *mongoStore) ReadRevision(ctx context.Context, blogID primitive.ObjectID, version int64) (*revisionItem, error) {
+	rev := &revisionItem{}
+	res := s.revisions.FindOne(ctx, bson.M{"blog_id": blogID, "version": version})
+	if err := res.Decode(rev); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errRevisionNotFound
+		}
+		return nil, err
+	}
+	return normalizeLegacyRevision(rev), nil
+}
+
+// normalizeLegacyRevision gives the revisions recorded before their state was kept their implicit PUBLISHED state
+func normalizeLegacyRevision(rev *revisionItem) *revisionItem {
+	if rev.State == blogpb.Blog_STATE_UNSPECIFIED {
+		rev.State = blogpb.Blog_PUBLISHED
+	}
+	return rev
+}
+
+func (s *mongoStore) CreateComment(ctx context.Context, comment *commentItem) (*commentItem, error) {
+	res, err := s.comments.InsertOne(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert to OID")
+	}
+	created := *comment
+	created.ID = oid
+	return &created, nil
+}
+
+func (s *mongoStore) ReadComment(ctx context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID) (*commentItem, error) {
+	comment := &commentItem{}
+	res := s.comments.FindOne(ctx, bson.M{"_id": commentID, "blog_id": blogID})
+	if err := res.Decode(comment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errCommentNotFound
+		}
+		return nil, err
+	}
+	return normalizeComment(comment), nil
+}
+
+func (s *mongoStore) ListComments(ctx context.Context, q commentQuery) ([]*commentItem, error) {
+	filter := commentStateFilter(q.State)
+	if !q.BlogID.IsZero() {
+		filter["blog_id"] = q.BlogID
+	}
+	if !q.After.IsZero() {
+		filter["_id"] = bson.M{"$gt": q.After}
+	}
+
+	var cur *mongo.Cursor
+	var err error
+	if q.BlogID.IsZero() {
+		// the comments of every blog leave out those of blogs in the trash, found in the blog collection
+		cur, err = s.comments.Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: filter}},
+			{{Key: "$sort", Value: bson.M{"_id": 1}}},
+			{{Key: "$lookup", Value: bson.M{"from": s.collection.Name(), "localField": "blog_id", "foreignField": "_id", "as": "blog"}}},
+			{{Key: "$match", Value: bson.M{"blog.delete_time": nil}}},
+			{{Key: "$limit", Value: q.Limit}},
+			{{Key: "$project", Value: bson.M{"blog": 0}}},
+		})
+	} else {
+		opts := options.Find().
+			SetSort(primitive.D{{Key: "_id", Value: 1}}).
+			SetLimit(int64(q.Limit))
+		cur, err = s.comments.Find(ctx, filter, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx) // Should handle err
+
+	var comments []*commentItem
+	for cur.Next(ctx) {
+		comment := &commentItem{}
+		if err := cur.Decode(comment); err != nil {
+			return nil, fmt.Errorf("error while decoding data from MongoDB: %v", err)
+		}
+		comments = append(comments, normalizeComment(comment))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *mongoStore) SetCommentState(ctx context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID, state blogpb.Comment_State) (*commentItem, error) {
+	filter := bson.M{"_id": commentID, "blog_id": blogID}
+	update := bson.M{"$set": bson.M{"state": state}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	comment := &commentItem{}
+	if err := s.comments.FindOneAndUpdate(ctx, filter, update, opts).Decode(comment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errCommentNotFound
+		}
+		return nil, err
+	}
+	return normalizeComment(comment), nil
+}
+
+func (s *mongoStore) HasApprovedComment(ctx context.Context, authorID string) (bool, error) {
+	filter := commentStateFilter(blogpb.Comment_APPROVED)
+	filter["author_id"] = authorID
+	n, err := s.comments.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (s *mongoStore) DeleteComment(ctx context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID) error {
+	n, err := s.comments.CountDocuments(ctx, bson.M{"_id": commentID, "blog_id": blogID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errCommentNotFound
+	}
+
+	// walk down the thread one level at a time, replies cannot nest deeper than maxCommentDepth
+	ids := []interface{}{commentID}
+	for level := ids; len(level) > 0; {
+		replies, err := s.comments.Distinct(ctx, "_id", bson.M{"blog_id": blogID, "parent_comment_id": bson.M{"$in": level}})
+		if err != nil {
+			return err
+		}
+		ids = append(ids, replies...)
+		level = replies
+	}
+	_, err = s.comments.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// normalizeComment gives comments written before moderation their implicit APPROVED state
+func normalizeComment(comment *commentItem) *commentItem {
+	if comment.State == blogpb.Comment_STATE_UNSPECIFIED {
+		comment.State = blogpb.Comment_APPROVED
+	}
+	return comment
+}
+
+// commentStateFilter matches the comments in the given state, counting comments without a state as approved
+func commentStateFilter(state blogpb.Comment_State) bson.M {
+	if state == blogpb.Comment_APPROVED {
+		return bson.M{"state": bson.M{"$in": []interface{}{state, nil}}}
+	}
+	return bson.M{"state": state}
+}
+
+// conflictError tells apart a missing blog from a version mismatch after a conditional update matched nothing
+func (s *mongoStore) conflictError(ctx context.Context, id primitive.ObjectID) error {
+	n, err := s.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errBlogNotFound
+	}
+	return errVersionMismatch
+}
+
+// versionFilter matches the blog with the given ID only while it is at the given version.
+// Blogs written before versioning have no version field and count as version 1.
+func versionFilter(id primitive.ObjectID, version int64) bson.M {
+	if version == 1 {
+		return bson.M{"_id": id, "$or": []bson.M{
+			{"version": 1},
+			{"version": bson.M{"$exists": false}},
+		}}
+	}
+	return bson.M{"_id": id, "version": version}
+}
+
+// normalizeLegacy gives blogs written before versioning their implicit version of 1,
+// and the ones written before states existed their implicit PUBLISHED state
+func normalizeLegacy(data *blogItem) *blogItem {
+	if data.Version == 0 {
+		data.Version = 1
+	}
+	if data.State == blogpb.Blog_STATE_UNSPECIFIED {
+		data.State = blogpb.Blog_PUBLISHED
+	}
+	return data
+}
+
+// stateFilter matches the blogs in the given state, counting blogs without a state as published
+func stateFilter(state blogpb.Blog_State) bson.M {
+	if state == blogpb.Blog_PUBLISHED {
+		return bson.M{"state": bson.M{"$in": []interface{}{state, nil}}}
+	}
+	return bson.M{"state": state}
+}
+
+func (s *mongoStore) List(ctx context.Context, q listQuery) ([]*blogItem, error) {
+	filter, err := mongoListFilter(q)
+	if err != nil {
+		return nil, err
+	}
+	direction := 1
+	if q.Order.Desc {
+		direction = -1
+	}
+	sort := primitive.D{}
+	if key := q.Order.sortKey(); key.field != "_id" {
+		sort = append(sort, primitive.E{Key: key.field, Value: direction})
+	}
+	sort = append(sort, primitive.E{Key: "_id", Value: direction})
+	opts := options.Find().
+		SetSort(sort).
+		SetLimit(int64(q.Limit))
+
+	cur, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx) // Should handle err
+
+	var items []*blogItem
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, fmt.Errorf("error while decoding data from MongoDB: %v", err)
+		}
+		items = append(items, normalizeLegacy(data))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// mongoListFilter translates the filter and cursor of q into a MongoDB query
+func mongoListFilter(q listQuery) (bson.M, error) {
+	var and []bson.M
+	if q.Filter.AuthorID != "" {
+		and = append(and, bson.M{"author_id": q.Filter.AuthorID})
+	}
+	if q.Filter.Title != "" {
+		and = append(and, bson.M{"title": q.Filter.Title})
+	}
+	if q.Filter.TitlePrefix != "" {
+		and = append(and, bson.M{"title": bson.M{"$regex": "^" + regexp.QuoteMeta(q.Filter.TitlePrefix)}})
+	}
+	if len(q.Filter.Tags) > 0 {
+		op := "$all"
+		if q.Filter.AnyTag {
+			op = "$in"
+		}
+		and = append(and, bson.M{"tags": bson.M{op: q.Filter.Tags}})
+	}
+	if q.Filter.State != blogpb.Blog_STATE_UNSPECIFIED {
+		and = append(and, stateFilter(q.Filter.State))
+	}
+	if q.Filter.Deleted != nil {
+		if *q.Filter.Deleted {
+			and = append(and, bson.M{"delete_time": bson.M{"$ne": nil}})
+		} else {
+			// matches blogs without the field as well
+			and = append(and, bson.M{"delete_time": nil})
+		}
+	}
+
+	if q.After != nil {
+		key := q.Order.sortKey()
+		if key.field == "_id" {
+			cmp := "$gt"
+			if q.Order.Desc {
+				cmp = "$lt"
+			}
+			and = append(and, bson.M{"_id": bson.M{cmp: q.After.ID}})
+		} else {
+			value, err := key.parse(q.After.Key)
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, afterCursorFilter(key.field, value, q.After.ID, q.Order.Desc))
+		}
+	}
+
+	if len(and) == 0 {
+		return bson.M{}, nil
+	}
+	return bson.M{"$and": and}, nil
+}
+
+// afterCursorFilter matches the blogs coming after a cursor in a listing ordered by field, then by ID:
+// either strictly after on the field, or a tie broken by ID.
+// MongoDB sorts the blogs without the field, written before it existed, before all the others,
+// and comparisons never match them, so they get their own branch. A nil value stands for them in cursors.
+func afterCursorFilter(field string, value interface{}, id primitive.ObjectID, desc bool) bson.M {
+	cmp := "$gt"
+	if desc {
+		cmp = "$lt"
+	}
+	tie := bson.M{field: value, "_id": bson.M{cmp: id}}
+	switch {
+	case value == nil && desc:
+		return tie
+	case value == nil:
+		// every blog with the field comes after the ones without it
+		return bson.M{"$or": []bson.M{{field: bson.M{"$ne": nil}}, tie}}
+	case desc:
+		// the blogs without the field come last
+		return bson.M{"$or": []bson.M{{field: bson.M{cmp: value}}, tie, {field: nil}}}
+	default:
+		return bson.M{"$or": []bson.M{{field: bson.M{cmp: value}}, tie}}
+	}
+}
+
+func (s *mongoStore) ReadByRequestID(ctx context.Context, key requestKey, now time.Time) (*blogItem, error) {
+	filter := requestFilter(key)
+	filter["request.expire_time"] = bson.M{"$gt": now}
+	data := &blogItem{}
+	if err := s.collection.FindOne(ctx, filter).Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errBlogNotFound
+		}
+		return nil, err
+	}
+	return normalizeLegacy(data), nil
+}
+
+// requestFilter matches the blog holding a CreateBlog request ID, expired or not
+func requestFilter(key requestKey) bson.M {
+	return bson.M{"request.caller_id": key.CallerID, "request.request_id": key.RequestID}
+}
+
+func (s *mongoStore) ListScheduled(ctx context.Context, before time.Time, limit int) ([]*blogItem, error) {
+	filter := bson.M{
+		"state":        blogpb.Blog_SCHEDULED,
+		"publish_time": bson.M{"$lte": before},
+		"delete_time":  nil,
+	}
+	opts := options.Find().
+		SetSort(primitive.D{{Key: "publish_time", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cur, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx) // Should handle err
+
+	var items []*blogItem
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, fmt.Errorf("error while decoding data from MongoDB: %v", err)
+		}
+		items = append(items, normalizeLegacy(data))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *mongoStore) CountTags(ctx context.Context) ([]tagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$and": []bson.M{{"delete_time": nil}, stateFilter(blogpb.Blog_PUBLISHED)}}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: primitive.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	cur, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx) // Should handle err
+
+	var counts []tagCount
+	if err := cur.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("error while decoding data from MongoDB: %v", err)
+	}
+	return counts, nil
+}
+
+func (s *mongoStore) Search(ctx context.Context, query string, offset, limit int) ([]*searchHit, error) {
+	filter := bson.M{"$and": []bson.M{
+		{"$text": bson.M{"$search": query}},
+		{"delete_time": nil},
+		stateFilter(blogpb.Blog_PUBLISHED),
+	}}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(primitive.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cur, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx) // Should handle err
+
+	var hits []*searchHit
+	for cur.Next(ctx) {
+		var doc struct {
+			Blog  blogItem `bson:",inline"`
+			Score float64  `bson:"score"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("error while decoding data from MongoDB: %v", err)
+		}
+		hits = append(hits, &searchHit{Blog: normalizeLegacy(&doc.Blog), Score: doc.Score})
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// Watch relies on MongoDB change streams, which need the server to run as a replica set
+func (s *mongoStore) Watch(ctx context.Context, resumeToken string, fn func(blogEvent) error) error {
+	opts := options.ChangeStream()
+	if resumeToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err != nil || mongobson.Raw(raw).Validate() != nil {
+			return errInvalidResumeToken
+		}
+		opts.SetResumeAfter(mongobson.Raw(raw))
+	}
+	// purges are hard deletes of blogs that were already reported as DELETED
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": []string{"insert", "replace"}}}}}}
+
+	cs, err := s.collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return changeStreamError(err)
+	}
+	defer cs.Close(context.Background())
+
+	for cs.Next(ctx) {
+		var change struct {
+			OperationType string    `bson:"operationType"`
+			FullDocument  *blogItem `bson:"fullDocument"`
+		}
+		if err := cs.Decode(&change); err != nil {
+			return fmt.Errorf("error while decoding change from MongoDB: %v", err)
+		}
+		err := fn(blogEvent{
+			Type:        eventTypeOf(change.FullDocument, change.OperationType == "insert"),
+			Blog:        normalizeLegacy(change.FullDocument),
+			ResumeToken: base64.RawURLEncoding.EncodeToString(cs.ResumeToken()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := cs.Err(); err != nil {
+		return changeStreamError(err)
+	}
+	return ctx.Err()
+}
+
+// changeStreamError reports resume tokens that fell off the oplog as errResumeTokenExpired
+func changeStreamError(err error) error {
+	// ChangeStreamHistoryLost and ChangeStreamFatalError
+	if se, ok := err.(mongo.ServerError); ok && (se.HasErrorCode(286) || se.HasErrorCode(280)) {
+		return errResumeTokenExpired
+	}
+	return err
+}
+
+func (s *mongoStore) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultPageSize is used when a ListBlog request does not set a page size
+	defaultPageSize = 50
+	// maxPageSize caps the page size a client can ask for
+	maxPageSize = 500
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is the cursor handed to clients as an opaque next_page_token.
+// It points at the last blog of a page rather than at an offset,
+// so blogs inserted concurrently do not shift the pages already read.
+// With the default ID order new blogs always land after the cursor since ObjectIDs grow over time.
+type pageToken struct {
+	LastID  primitive.ObjectID `json:"last_id"`
+	LastKey string             `json:"last_key,omitempty"`
+	// Query identifies the filter and order the token was issued for
+	Query string `json:"query"`
+}
+
+func (t pageToken) cursor() *listCursor {
+	if t.LastID.IsZero() {
+		return nil
+	}
+	return &listCursor{ID: t.LastID, Key: t.LastKey}
+}
+
+// cursorOf returns the position of data in a listing ordered by key
+func cursorOf(data *blogItem, key sortKey) listCursor {
+	c := listCursor{ID: data.ID}
+	if key.value != nil {
+		c.Key = key.value(data)
+	}
+	return c
+}
+
+func (t pageToken) encode() string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken parses a token, checking it was issued for the same query
+func decodePageToken(s string, query string) (pageToken, error) {
+	var t pageToken
+	if s == "" {
+		return t, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, errInvalidPageToken
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return t, errInvalidPageToken
+	}
+	if t.Query != query {
+		return t, errors.New("page token was issued for a different filter or order_by")
+	}
+	return t, nil
+}
+
+// pageSize returns the effective page size for the requested one
+func pageSize(requested int32) int {
+	switch {
+	case requested == 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
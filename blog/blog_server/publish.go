@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// publish moves a blog to PUBLISHED, or to SCHEDULED when publishTime is in the future
+func publish(data *blogItem, publishTime *time.Time, now time.Time) {
+	if publishTime != nil && publishTime.After(now) {
+		data.State = blogpb.Blog_SCHEDULED
+		data.PublishTime = publishTime
+		return
+	}
+	data.State = blogpb.Blog_PUBLISHED
+	data.PublishTime = &now
+}
+
+// publishTimeOf converts the publish time of a request, which may not be set
+func publishTimeOf(ts *timestamp.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Invalid publish time: %v", err,
+		)
+	}
+	t = t.UTC().Truncate(time.Millisecond)
+	return &t, nil
+}
+
+func (s *server) PublishBlog(ctx context.Context, req *blogpb.PublishBlogRequest) (*blogpb.PublishBlogResponse, error) {
+	fmt.Println("Publish blog request")
+	publishTime, err := publishTimeOf(req.GetPublishTime())
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.readForStateChange(ctx, req.GetBlogId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	if data.State == blogpb.Blog_PUBLISHED {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"Blog %v is already published", req.GetBlogId(),
+		)
+	}
+
+	publish(data, publishTime, timeNow())
+	published, err := s.store.Update(ctx, data)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &blogpb.PublishBlogResponse{
+		Blog: dataToBlogPb(published),
+	}, nil
+}
+
+func (s *server) UnpublishBlog(ctx context.Context, req *blogpb.UnpublishBlogRequest) (*blogpb.UnpublishBlogResponse, error) {
+	fmt.Println("Unpublish blog request")
+	data, err := s.readForStateChange(ctx, req.GetBlogId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	state := blogpb.Blog_DRAFT
+	if req.GetArchive() {
+		state = blogpb.Blog_ARCHIVED
+	}
+	if data.State == state {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"Blog %v is already in state %v", req.GetBlogId(), state,
+		)
+	}
+	// archived blogs keep their publish time as a record of when they went out
+	if state == blogpb.Blog_DRAFT || data.State == blogpb.Blog_SCHEDULED {
+		data.PublishTime = nil
+	}
+	data.State = state
+	unpublished, err := s.store.Update(ctx, data)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &blogpb.UnpublishBlogResponse{
+		Blog: dataToBlogPb(unpublished),
+	}, nil
+}
+
+// readForStateChange reads the live blog targeted by PublishBlog or UnpublishBlog,
+// checking the caller can change it and it is still at version unless it is 0
+func (s *server) readForStateChange(ctx context.Context, blogID string, version int64) (*blogItem, error) {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse ID",
+		)
+	}
+	if version < 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Version cannot be negative: %v", version,
+		)
+	}
+
+	data, err := s.readLiveBlog(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if _, err := requireAuthor(ctx, data.AuthorID); err != nil {
+		return nil, err
+	}
+	if version != 0 && data.Version != version {
+		return nil, storeError(errVersionMismatch)
+	}
+	return data, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runPurger permanently removes the blogs that have been in the trash for longer than retention,
+// checking every interval until ctx is done
+func runPurger(ctx context.Context, store BlogStore, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := store.PurgeDeleted(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("Error while purging deleted blogs: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %v deleted blogs", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
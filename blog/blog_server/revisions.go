@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// revisionItem is the state of a blog at a given version, recorded before the blog is updated
+type revisionItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	BlogID     primitive.ObjectID `bson:"blog_id"`
+	Version    int64              `bson:"version"`
+	AuthorID   string             `bson:"author_id"`
+	Content    string             `bson:"content"`
+	Title      string             `bson:"title"`
+	UpdateTime time.Time          `bson:"update_time"`
+	Tags       []string           `bson:"tags,omitempty"`
+	// State is the state of the blog at that version, revisions recorded before it was kept count as PUBLISHED
+	State blogpb.Blog_State `bson:"state"`
+}
+
+func revisionOf(data *blogItem) *revisionItem {
+	return &revisionItem{
+		BlogID:     data.ID,
+		Version:    data.Version,
+		AuthorID:   data.AuthorID,
+		Content:    data.Content,
+		Title:      data.Title,
+		UpdateTime: data.UpdateTime,
+		Tags:       data.Tags,
+		State:      data.State,
+	}
+}
+
+// publishedRevisionsOnly tells if the caller may only see the revisions of a blog made while it was published.
+// Drafts are private to their author and the admins, and so are their past versions.
+func publishedRevisionsOnly(c *caller, data *blogItem) bool {
+	return c == nil || !c.canManage(data.AuthorID)
+}
+
+func revisionToPb(rev *revisionItem) *blogpb.BlogRevision {
+	return &blogpb.BlogRevision{
+		BlogId:     rev.BlogID.Hex(),
+		Version:    rev.Version,
+		AuthorId:   rev.AuthorID,
+		Content:    rev.Content,
+		Title:      rev.Title,
+		UpdateTime: timeToPb(rev.UpdateTime),
+		Tags:       rev.Tags,
+	}
+}
+
+// updateWithRevision saves the current state of a blog as a revision, then updates it.
+// The revision is recorded first so a crash in between never loses history,
+// and since a version is recorded only once, a concurrent update that ends up ABORTED leaves nothing behind.
+func (s *server) updateWithRevision(ctx context.Context, current *blogItem, updated *blogItem) (*blogItem, error) {
+	if err := s.store.AddRevision(ctx, revisionOf(current)); err != nil {
+		return nil, err
+	}
+	return s.store.Update(ctx, updated)
+}
+
+func (s *server) ListBlogRevisions(req *blogpb.ListBlogRevisionsRequest, stream blogpb.BlogService_ListBlogRevisionsServer) error {
+	fmt.Println("List blog revisions request")
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse ID",
+		)
+	}
+	if req.GetPageSize() < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Page size cannot be negative: %v", req.GetPageSize(),
+		)
+	}
+	query := "revisions:" + oid.Hex()
+	token, err := decodePageToken(req.GetPageToken(), query)
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse page token: %v", err,
+		)
+	}
+	var before int64
+	if token.LastKey != "" {
+		if before, err = strconv.ParseInt(token.LastKey, 10, 64); err != nil {
+			return status.Errorf(
+				codes.InvalidArgument,
+				"Cannot parse page token: %v", errInvalidPageToken,
+			)
+		}
+	}
+	size := pageSize(req.GetPageSize())
+
+	ctx := stream.Context()
+	data, err := s.readVisibleBlog(ctx, oid)
+	if err != nil {
+		return storeError(err)
+	}
+	publishedOnly := publishedRevisionsOnly(callerFromContext(ctx), data)
+
+	// we ask for one more revision than needed to know if there is a next page
+	revs, err := s.store.ListRevisions(ctx, oid, before, size+1, publishedOnly)
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			"Unknown internal error: %v", err,
+		)
+	}
+	hasMore := len(revs) > size
+	if hasMore {
+		revs = revs[:size]
+	}
+
+	for i, rev := range revs {
+		res := &blogpb.ListBlogRevisionsResponse{Revision: revisionToPb(rev)}
+		if hasMore && i == len(revs)-1 {
+			res.NextPageToken = pageToken{
+				LastID:  oid,
+				LastKey: strconv.FormatInt(rev.Version, 10),
+				Query:   query,
+			}.encode()
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) GetBlogRevision(ctx context.Context, req *blogpb.GetBlogRevisionRequest) (*blogpb.GetBlogRevisionResponse, error) {
+	fmt.Println("Get blog revision request")
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse ID",
+		)
+	}
+
+	data, err := s.readVisibleBlog(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	rev, err := s.store.ReadRevision(ctx, oid, req.GetVersion())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if rev.State != blogpb.Blog_PUBLISHED && publishedRevisionsOnly(callerFromContext(ctx), data) {
+		return nil, storeError(errRevisionNotFound)
+	}
+
+	return &blogpb.GetBlogRevisionResponse{
+		Revision: revisionToPb(rev),
+	}, nil
+}
+
+func (s *server) RestoreBlogRevision(ctx context.Context, req *blogpb.RestoreBlogRevisionRequest) (*blogpb.RestoreBlogRevisionResponse, error) {
+	fmt.Println("Restore blog revision request")
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse ID",
+		)
+	}
+	if req.GetBlogVersion() < 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Blog version cannot be negative: %v", req.GetBlogVersion(),
+		)
+	}
+
+	data, err := s.readLiveBlog(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if _, err := requireAuthor(ctx, data.AuthorID); err != nil {
+		return nil, err
+	}
+	if req.GetBlogVersion() != 0 && data.Version != req.GetBlogVersion() {
+		return nil, storeError(errVersionMismatch)
+	}
+	rev, err := s.store.ReadRevision(ctx, oid, req.GetVersion())
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	// restoring is an update like any other, so the current state becomes a revision too.
+	// The blog keeps its author, ownership is not part of the content being restored.
+	restored := *data
+	restored.Content = rev.Content
+	restored.Title = rev.Title
+	restored.Tags = rev.Tags
+	restored.UpdateTime = timeNow()
+
+	updated, err := s.updateWithRevision(ctx, data, &restored)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &blogpb.RestoreBlogRevisionResponse{
+		Blog: dataToBlogPb(updated),
+	}, nil
+}
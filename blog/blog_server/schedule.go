@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// scheduleBatchSize is how many scheduled blogs are published per store query
+const scheduleBatchSize = 100
+
+// runScheduler publishes the scheduled blogs whose publish time has come,
+// checking every interval until ctx is done
+func runScheduler(ctx context.Context, store BlogStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := publishDue(ctx, store, timeNow())
+		if err != nil {
+			log.Printf("Error while publishing scheduled blogs: %v", err)
+		} else if published > 0 {
+			log.Printf("Published %v scheduled blogs", published)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishDue publishes the blogs scheduled up to now and returns how many it published
+func publishDue(ctx context.Context, store BlogStore, now time.Time) (int, error) {
+	published := 0
+	for {
+		due, err := store.ListScheduled(ctx, now, scheduleBatchSize)
+		if err != nil {
+			return published, err
+		}
+		skipped := 0
+		for _, data := range due {
+			data.State = blogpb.Blog_PUBLISHED
+			if _, err := store.Update(ctx, data); err != nil {
+				if err == errVersionMismatch || err == errBlogNotFound {
+					// changed since we listed it, e.g. rescheduled, the next run looks at it again
+					skipped++
+					continue
+				}
+				return published, err
+			}
+			published++
+		}
+		if len(due) < scheduleBatchSize || skipped > 0 {
+			return published, nil
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+const (
+	// maxSearchQueryLength keeps queries to a reasonable size
+	maxSearchQueryLength = 256
+	// snippetLength is the approximate number of bytes of a snippet, highlighting excluded
+	snippetLength = 160
+	// snippetContextWords is how many words a snippet shows before the first match
+	snippetContextWords = 5
+	// titleWeight makes a word found in the title count more than one found in the content
+	titleWeight = 2
+)
+
+func (s *server) SearchBlogs(req *blogpb.SearchBlogsRequest, stream blogpb.BlogService_SearchBlogsServer) error {
+	fmt.Println("Search blogs request")
+
+	query := strings.TrimSpace(req.GetQuery())
+	if len(query) > maxSearchQueryLength {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Query cannot be longer than %v characters", maxSearchQueryLength,
+		)
+	}
+	parsed := parseSearchQuery(query)
+	if len(parsed.terms) == 0 {
+		// MongoDB finds nothing for a query that only excludes words
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Query must contain at least one word that is not excluded",
+		)
+	}
+	if req.GetPageSize() < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Page size cannot be negative: %v", req.GetPageSize(),
+		)
+	}
+	// ranked results have no stable cursor, so pages are tracked with an offset
+	tokenQuery := "search:" + query
+	token, err := decodePageToken(req.GetPageToken(), tokenQuery)
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse page token: %v", err,
+		)
+	}
+	offset := 0
+	if token.LastKey != "" {
+		if offset, err = strconv.Atoi(token.LastKey); err != nil || offset < 0 {
+			return status.Errorf(
+				codes.InvalidArgument,
+				"Cannot parse page token: %v", errInvalidPageToken,
+			)
+		}
+	}
+	size := pageSize(req.GetPageSize())
+
+	// we ask for one more hit than needed to know if there is a next page
+	hits, err := s.store.Search(stream.Context(), query, offset, size+1)
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			"Unknown internal error: %v", err,
+		)
+	}
+	hasMore := len(hits) > size
+	if hasMore {
+		hits = hits[:size]
+	}
+
+	for i, hit := range hits {
+		res := &blogpb.SearchBlogsResponse{
+			Blog:    dataToBlogPb(hit.Blog),
+			Score:   hit.Score,
+			Snippet: snippet(hit.Blog, parsed.terms),
+		}
+		if hasMore && i == len(hits)-1 {
+			res.NextPageToken = pageToken{
+				LastKey: strconv.Itoa(offset + len(hits)),
+				Query:   tokenQuery,
+			}.encode()
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// word is a word of a text, with its position in bytes
+type word struct {
+	term       string
+	start, end int
+}
+
+// splitWords cuts a text into lower case words made of letters and digits
+func splitWords(text string) []word {
+	var words []word
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWordRune && start < 0:
+			start = i
+		case !isWordRune && start >= 0:
+			words = append(words, word{term: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{term: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return words
+}
+
+// searchQuery is a SearchBlogs query, with the syntax of MongoDB text searches so that both stores agree:
+// a blog matches if it contains any of the words, every "quoted phrase", and none of the -excluded words or -"phrases"
+type searchQuery struct {
+	// terms are the distinct words to look for, including the words of the phrases
+	terms   map[string]bool
+	phrases [][]string
+	// excluded holds the excluded phrases, an excluded word being a phrase of one word
+	excluded [][]string
+}
+
+// parseSearchQuery splits a query into words and phrases, a phrase missing its closing quote running to the end
+func parseSearchQuery(query string) searchQuery {
+	q := searchQuery{terms: make(map[string]bool)}
+	rest := query
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			return q
+		}
+		negated := strings.HasPrefix(rest, "-")
+		if negated {
+			rest = rest[1:]
+		}
+		quoted := strings.HasPrefix(rest, `"`)
+		var text string
+		if quoted {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				text, rest = rest[1:], ""
+			} else {
+				text, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			text, rest = rest[:end], rest[end:]
+		}
+
+		var words []string
+		for _, w := range splitWords(text) {
+			words = append(words, w.term)
+		}
+		switch {
+		case len(words) == 0:
+		case negated && quoted:
+			q.excluded = append(q.excluded, words)
+		case negated:
+			for _, w := range words {
+				q.excluded = append(q.excluded, []string{w})
+			}
+		default:
+			if quoted {
+				q.phrases = append(q.phrases, words)
+			}
+			for _, w := range words {
+				q.terms[w] = true
+			}
+		}
+	}
+}
+
+// matches tells if the title or the content of a blog has every phrase of the query, and neither has an excluded one
+func (q searchQuery) matches(data *blogItem) bool {
+	title, content := splitWords(data.Title), splitWords(data.Content)
+	found := func(phrase []string) bool {
+		return containsPhrase(title, phrase) || containsPhrase(content, phrase)
+	}
+	for _, phrase := range q.phrases {
+		if !found(phrase) {
+			return false
+		}
+	}
+	for _, phrase := range q.excluded {
+		if found(phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsPhrase tells if the words of phrase follow each other in words
+func containsPhrase(words []word, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		j := 0
+		for j < len(phrase) && words[i+j].term == phrase[j] {
+			j++
+		}
+		if j == len(phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// snippet returns an excerpt of the blog around the first matching word, with the matches highlighted.
+// The content is preferred, and the title is used when only the title matches.
+func snippet(data *blogItem, terms map[string]bool) string {
+	for _, text := range []string{data.Content, data.Title} {
+		words := splitWords(text)
+		for i, w := range words {
+			if terms[w.term] {
+				return highlight(text, words, i, terms)
+			}
+		}
+	}
+	// the store matched on something we cannot see, such as a stemmed word
+	return highlight(data.Content, splitWords(data.Content), 0, terms)
+}
+
+// highlight cuts about snippetLength bytes of text, starting a few words before words[first],
+// and wraps the words matching terms in <em></em>.
+// The snippet is HTML: everything taken from the blog is escaped, so only the highlighting is markup.
+func highlight(text string, words []word, first int, terms map[string]bool) string {
+	if len(words) == 0 {
+		return ""
+	}
+	from := first - snippetContextWords
+	if from < 0 {
+		from = 0
+	}
+	start := words[from].start
+	if from == 0 {
+		start = 0
+	}
+	end := len(text)
+	if end-start > snippetLength {
+		// stop after the last word that fits
+		end = words[from].end
+		for _, w := range words[from+1:] {
+			if w.end-start > snippetLength {
+				break
+			}
+			end = w.end
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	pos := start
+	for _, w := range words[from:] {
+		if w.end > end {
+			break
+		}
+		if terms[w.term] {
+			b.WriteString(html.EscapeString(text[pos:w.start]))
+			b.WriteString("<em>" + html.EscapeString(text[w.start:w.end]) + "</em>")
+			pos = w.end
+		}
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// searchIndex is an inverted index over the title and content of blogs, used by memoryStore.
+// It is not safe for concurrent use.
+type searchIndex struct {
+	// postings maps each term to its weighted frequency in every blog containing it
+	postings map[string]map[primitive.ObjectID]float64
+	// terms keeps the terms of each indexed blog so it can be removed from postings
+	terms map[primitive.ObjectID][]string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[primitive.ObjectID]float64),
+		terms:    make(map[primitive.ObjectID][]string),
+	}
+}
+
+// add indexes a blog, replacing what was indexed for it before
+func (x *searchIndex) add(data *blogItem) {
+	x.remove(data.ID)
+
+	freqs := make(map[string]float64)
+	for _, w := range splitWords(data.Title) {
+		freqs[w.term] += titleWeight
+	}
+	for _, w := range splitWords(data.Content) {
+		freqs[w.term]++
+	}
+
+	terms := make([]string, 0, len(freqs))
+	for term, freq := range freqs {
+		if x.postings[term] == nil {
+			x.postings[term] = make(map[primitive.ObjectID]float64)
+		}
+		x.postings[term][data.ID] = freq
+		terms = append(terms, term)
+	}
+	x.terms[data.ID] = terms
+}
+
+func (x *searchIndex) remove(id primitive.ObjectID) {
+	for _, term := range x.terms[id] {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	delete(x.terms, id)
+}
+
+// search scores the blogs containing any of the terms with TF-IDF,
+// so words found in fewer blogs weigh more
+func (x *searchIndex) search(terms map[string]bool) map[primitive.ObjectID]float64 {
+	scores := make(map[primitive.ObjectID]float64)
+	total := float64(len(x.terms))
+	for term := range terms {
+		postings := x.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		idf := math.Log(1 + total/float64(len(postings)))
+		for id, freq := range postings {
+			scores[id] += freq * idf
+		}
+	}
+	return scores
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		query   string
+		want    string
+	}{
+		{"highlight", "hello world", "world", "hello <em>world</em>"},
+		{"case is ignored", "Hello World", "hello", "<em>Hello</em> World"},
+		{"no match", "hello world", "nope", "hello world"},
+		{"markup is escaped", "<script>alert(1)</script> hello world & <b>", "hello", "&lt;script&gt;alert(1)&lt;/script&gt; <em>hello</em> world &amp; &lt;b&gt;"},
+		{"highlighted words are escaped", "say \"hi\"", "hi", "say &#34;<em>hi</em>&#34;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snippet(&blogItem{Content: tt.content}, parseSearchQuery(tt.query).terms); got != tt.want {
+				t.Errorf("snippet(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		terms    []string
+		phrases  [][]string
+		excluded [][]string
+	}{
+		{"Go rust go", []string{"go", "rust"}, nil, nil},
+		{`go "Hello, World"`, []string{"go", "hello", "world"}, [][]string{{"hello", "world"}}, nil},
+		{`go -rust -"bad news"`, []string{"go"}, nil, [][]string{{"rust"}, {"bad", "news"}}},
+		{"e-mail", []string{"e", "mail"}, nil, nil},
+		{`-foo-bar "open`, []string{"open"}, [][]string{{"open"}}, [][]string{{"foo"}, {"bar"}}},
+		{`- "" -`, nil, nil, nil},
+	}
+	for _, tt := range tests {
+		q := parseSearchQuery(tt.query)
+		terms := make(map[string]bool)
+		for _, term := range tt.terms {
+			terms[term] = true
+		}
+		if !reflect.DeepEqual(q.terms, terms) || !reflect.DeepEqual(q.phrases, tt.phrases) || !reflect.DeepEqual(q.excluded, tt.excluded) {
+			t.Errorf("parseSearchQuery(%q) = %v, %v, %v, want %v, %v, %v", tt.query, q.terms, q.phrases, q.excluded, tt.terms, tt.phrases, tt.excluded)
+		}
+	}
+}
@@ -2,30 +2,33 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"time"
 
-	"gopkg.in/mgo.v2/bson"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/simplesteph/grpc-go-course/auth"
 	"github.com/simplesteph/grpc-go-course/blog/blogpb"
 	"google.golang.org/grpc"
 )
 
-var collection *mongo.Collection
-
 type server struct {
 	blogpb.BlogServiceServer
+	store BlogStore
+	// requestIDWindow is how long CreateBlog remembers request IDs, defaultRequestIDWindow when 0
+	requestIDWindow time.Duration
 }
 
 type blogItem struct {
@@ -33,45 +36,98 @@ type blogItem struct {
 	AuthorID string             `bson:"author_id"`
 	Content  string             `bson:"content"`
 	Title    string             `bson:"title"`
+	Version  int64              `bson:"version"`
+	// CreateTime and UpdateTime are stored with millisecond precision like MongoDB dates
+	CreateTime time.Time `bson:"create_time"`
+	UpdateTime time.Time `bson:"update_time"`
+	// DeleteTime is only set while the blog is in the trash
+	DeleteTime *time.Time `bson:"delete_time,omitempty"`
+	Tags       []string   `bson:"tags,omitempty"`
+	// State is never STATE_UNSPECIFIED once read from a store
+	State blogpb.Blog_State `bson:"state"`
+	// PublishTime is set for scheduled blogs and the ones that were published
+	PublishTime *time.Time `bson:"publish_time,omitempty"`
+	// Request is set for the blogs created with a request ID
+	Request *blogRequest `bson:"request,omitempty"`
 }
 
-func (*server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
+func (s *server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
 	fmt.Println("Create blog request")
 	blog := req.GetBlog()
 
-	data := blogItem{
-		AuthorID: blog.GetAuthorId(),
-		Title:    blog.GetTitle(),
-		Content:  blog.GetContent(),
+	// blogs belong to whoever creates them, admins can create them on behalf of someone else
+	c, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authorID := c.AuthorID
+	if c.Admin && blog.GetAuthorId() != "" {
+		authorID = blog.GetAuthorId()
 	}
 
-	res, err := collection.InsertOne(ctx, data)
+	tags, err := normalizeTags(blog.GetTags())
 	if err != nil {
 		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
+			codes.InvalidArgument,
+			"Invalid tags: %v", err,
 		)
 	}
-	oid, ok := res.InsertedID.(primitive.ObjectID)
-	if !ok {
+
+	now := timeNow()
+	data := &blogItem{
+		AuthorID:   authorID,
+		Title:      blog.GetTitle(),
+		Content:    blog.GetContent(),
+		CreateTime: now,
+		UpdateTime: now,
+		Tags:       tags,
+		State:      blogpb.Blog_DRAFT,
+	}
+	switch blog.GetState() {
+	case blogpb.Blog_STATE_UNSPECIFIED, blogpb.Blog_DRAFT:
+	case blogpb.Blog_PUBLISHED, blogpb.Blog_SCHEDULED:
+		publishTime, err := publishTimeOf(blog.GetPublishTime())
+		if err != nil {
+			return nil, err
+		}
+		if blog.GetState() == blogpb.Blog_SCHEDULED && (publishTime == nil || !publishTime.After(now)) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Scheduled blogs need a publish time in the future",
+			)
+		}
+		publish(data, publishTime, now)
+	default:
 		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Cannot convert to OID"),
+			codes.InvalidArgument,
+			"Blogs cannot be created as %v", blog.GetState(),
 		)
 	}
 
+	var created *blogItem
+	if requestID := req.GetRequestId(); requestID != "" {
+		// a retry gets back what the first request created
+		created, err = s.createWithRequestID(ctx, c, requestID, data)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		created, err = s.store.Create(ctx, data)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"Internal error: %v", err,
+			)
+		}
+	}
+
 	return &blogpb.CreateBlogResponse{
-		Blog: &blogpb.Blog{
-			Id:       oid.Hex(),
-			AuthorId: blog.GetAuthorId(),
-			Title:    blog.GetTitle(),
-			Content:  blog.GetContent(),
-		},
+		Blog: dataToBlogPb(created),
 	}, nil
 
 }
 
-func (*server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
+func (s *server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
 	fmt.Println("Read blog request")
 
 	blogID := req.GetBlogId()
@@ -79,20 +135,13 @@ func (*server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blog
 	if err != nil {
 		return nil, status.Errorf(
 			codes.InvalidArgument,
-			fmt.Sprintf("Cannot parse ID"),
+			"Cannot parse ID",
 		)
 	}
 
-	// create an empty struct
-	data := &blogItem{}
-	filter := bson.M{"_id": oid}
-
-	res := collection.FindOne(ctx, filter)
-	if err := res.Decode(data); err != nil {
-		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Cannot find blog with specified ID: %v", err),
-		)
+	data, err := s.readVisibleBlog(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
 	}
 
 	return &blogpb.ReadBlogResponse{
@@ -102,144 +151,440 @@ func (*server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blog
 
 func dataToBlogPb(data *blogItem) *blogpb.Blog {
 	return &blogpb.Blog{
-		Id:       data.ID.Hex(),
-		AuthorId: data.AuthorID,
-		Content:  data.Content,
-		Title:    data.Title,
+		Id:          data.ID.Hex(),
+		AuthorId:    data.AuthorID,
+		Content:     data.Content,
+		Title:       data.Title,
+		Version:     data.Version,
+		CreateTime:  timeToPb(data.CreateTime),
+		UpdateTime:  timeToPb(data.UpdateTime),
+		DeleteTime:  optionalTimeToPb(data.DeleteTime),
+		Tags:        data.Tags,
+		State:       data.State,
+		PublishTime: optionalTimeToPb(data.PublishTime),
+	}
+}
+
+// timeNow returns the current time at the precision blog timestamps are stored with
+func timeNow() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// timeToPb converts a stored time to a Timestamp, leaving it unset for blogs written before timestamps existed
+func timeToPb(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+// optionalTimeToPb converts a time that may not be set
+func optionalTimeToPb(t *time.Time) *timestamp.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timeToPb(*t)
+}
+
+// readLiveBlog reads a blog that is not in the trash
+func (s *server) readLiveBlog(ctx context.Context, oid primitive.ObjectID) (*blogItem, error) {
+	data, err := s.store.Read(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	if data.DeleteTime != nil {
+		return nil, errBlogNotFound
+	}
+	return data, nil
+}
+
+// readVisibleBlog reads a live blog the caller may see.
+// Blogs that are not published look like they do not exist to the callers who cannot manage them.
+func (s *server) readVisibleBlog(ctx context.Context, oid primitive.ObjectID) (*blogItem, error) {
+	data, err := s.readLiveBlog(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	if !callerFromContext(ctx).canView(data) {
+		return nil, errBlogNotFound
+	}
+	return data, nil
+}
+
+// storeError converts an error returned by a BlogStore into a gRPC status error
+func storeError(err error) error {
+	switch err {
+	case errBlogNotFound:
+		return status.Errorf(
+			codes.NotFound,
+			"Cannot find blog with specified ID: %v", err,
+		)
+	case errRevisionNotFound:
+		return status.Errorf(
+			codes.NotFound,
+			"Cannot find revision with specified version: %v", err,
+		)
+	case errCommentNotFound:
+		return status.Errorf(
+			codes.NotFound,
+			"Cannot find comment with specified ID: %v", err,
+		)
+	case errVersionMismatch:
+		return status.Errorf(
+			codes.Aborted,
+			"Blog was modified concurrently, read it again and retry: %v", err,
+		)
 	}
+	return status.Errorf(
+		codes.Internal,
+		"Internal error: %v", err,
+	)
 }
 
-func (*server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
+func (s *server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
 	fmt.Println("Update blog request")
 	blog := req.GetBlog()
 	oid, err := primitive.ObjectIDFromHex(blog.GetId())
 	if err != nil {
 		return nil, status.Errorf(
 			codes.InvalidArgument,
-			fmt.Sprintf("Cannot parse ID"),
+			"Cannot parse ID",
 		)
 	}
-
-	// create an empty struct
-	data := &blogItem{}
-	filter := bson.M{"_id": oid}
-
-	res := collection.FindOne(ctx, filter)
-	if err := res.Decode(data); err != nil {
+	if blog.GetVersion() <= 0 {
 		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Cannot find blog with specified ID: %v", err),
+			codes.InvalidArgument,
+			"Missing version, pass the version of the blog that was read",
 		)
 	}
 
-	// we update our internal struct
-	data.AuthorID = blog.GetAuthorId()
-	data.Content = blog.GetContent()
-	data.Title = blog.GetTitle()
+	data, err := s.readLiveBlog(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	c, err := requireAuthor(ctx, data.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if data.Version != blog.GetVersion() {
+		return nil, storeError(errVersionMismatch)
+	}
 
-	_, updateErr := collection.ReplaceOne(context.Background(), filter, data)
-	if updateErr != nil {
+	// we update a copy of our internal struct, only touching the fields in the mask,
+	// and keep the current state to record it as a revision
+	changed := *data
+	if err := applyUpdateMask(&changed, blog, req.GetUpdateMask().GetPaths()); err != nil {
+		return nil, err
+	}
+	if changed.AuthorID != data.AuthorID && !c.Admin {
 		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Cannot update object in MongoDB: %v", updateErr),
+			codes.PermissionDenied,
+			"Only an admin can change the author of a blog",
 		)
 	}
+	changed.UpdateTime = timeNow()
+
+	updated, err := s.updateWithRevision(ctx, data, &changed)
+	if err != nil {
+		return nil, storeError(err)
+	}
 
 	return &blogpb.UpdateBlogResponse{
-		Blog: dataToBlogPb(data),
+		Blog: dataToBlogPb(updated),
 	}, nil
 
 }
 
-func (*server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
+// blogFieldUpdaters copy a single updatable field from a Blog message, keyed by field mask path
+var blogFieldUpdaters = map[string]func(data *blogItem, blog *blogpb.Blog) error{
+	"author_id": func(data *blogItem, blog *blogpb.Blog) error {
+		data.AuthorID = blog.GetAuthorId()
+		return nil
+	},
+	"title": func(data *blogItem, blog *blogpb.Blog) error {
+		data.Title = blog.GetTitle()
+		return nil
+	},
+	"content": func(data *blogItem, blog *blogpb.Blog) error {
+		data.Content = blog.GetContent()
+		return nil
+	},
+	"tags": func(data *blogItem, blog *blogpb.Blog) error {
+		tags, err := normalizeTags(blog.GetTags())
+		if err != nil {
+			return status.Errorf(
+				codes.InvalidArgument,
+				"Invalid tags: %v", err,
+			)
+		}
+		data.Tags = tags
+		return nil
+	},
+}
+
+var (
+	// implicitUpdatePaths are the fields updated without a mask, the ones blogs had before masks existed,
+	// so the full updates of older clients leave the newer fields alone
+	implicitUpdatePaths = []string{"title", "content"}
+	// wildcardUpdatePaths are the fields updated by "*". The author is left out,
+	// changing it always takes an explicit author_id path.
+	wildcardUpdatePaths = []string{"title", "content", "tags"}
+)
+
+// applyUpdateMask copies the fields listed in paths from blog to data
+func applyUpdateMask(data *blogItem, blog *blogpb.Blog, paths []string) error {
+	for _, path := range paths {
+		if _, ok := blogFieldUpdaters[path]; !ok && path != "*" {
+			return status.Errorf(
+				codes.InvalidArgument,
+				"Invalid update mask: unsupported path %q", path,
+			)
+		}
+		if path == "*" && len(paths) > 1 {
+			return status.Errorf(
+				codes.InvalidArgument,
+				"Invalid update mask: * cannot be combined with other paths",
+			)
+		}
+	}
+	switch {
+	case len(paths) == 0:
+		paths = implicitUpdatePaths
+	case paths[0] == "*":
+		paths = wildcardUpdatePaths
+	}
+	for _, path := range paths {
+		if err := blogFieldUpdaters[path](data, blog); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
 	fmt.Println("Delete blog request")
 	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
 	if err != nil {
 		return nil, status.Errorf(
 			codes.InvalidArgument,
-			fmt.Sprintf("Cannot parse ID"),
+			"Cannot parse ID",
+		)
+	}
+	if req.GetVersion() < 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Version cannot be negative: %v", req.GetVersion(),
 		)
 	}
 
-	filter := bson.M{"_id": oid}
+	data, err := s.readLiveBlog(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if _, err := requireAuthor(ctx, data.AuthorID); err != nil {
+		return nil, err
+	}
+	if req.GetVersion() != 0 && data.Version != req.GetVersion() {
+		return nil, storeError(errVersionMismatch)
+	}
+
+	// the blog is only moved to the trash, the purger removes it for good once the retention is over
+	now := timeNow()
+	data.DeleteTime = &now
+	if _, err := s.store.Update(ctx, data); err != nil {
+		return nil, storeError(err)
+	}
 
-	res, err := collection.DeleteOne(ctx, filter)
+	return &blogpb.DeleteBlogResponse{BlogId: req.GetBlogId()}, nil
+}
 
+func (s *server) UndeleteBlog(ctx context.Context, req *blogpb.UndeleteBlogRequest) (*blogpb.UndeleteBlogResponse, error) {
+	fmt.Println("Undelete blog request")
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
 	if err != nil {
 		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Cannot delete object in MongoDB: %v", err),
+			codes.InvalidArgument,
+			"Cannot parse ID",
+		)
+	}
+	if req.GetVersion() < 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Version cannot be negative: %v", req.GetVersion(),
 		)
 	}
 
-	if res.DeletedCount == 0 {
+	data, err := s.store.Read(ctx, oid)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if _, err := requireAuthor(ctx, data.AuthorID); err != nil {
+		return nil, err
+	}
+	if data.DeleteTime == nil {
 		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Cannot find blog in MongoDB: %v", err),
+			codes.FailedPrecondition,
+			"Blog %v is not deleted", req.GetBlogId(),
 		)
 	}
+	if req.GetVersion() != 0 && data.Version != req.GetVersion() {
+		return nil, storeError(errVersionMismatch)
+	}
 
-	return &blogpb.DeleteBlogResponse{BlogId: req.GetBlogId()}, nil
+	data.DeleteTime = nil
+	restored, err := s.store.Update(ctx, data)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &blogpb.UndeleteBlogResponse{
+		Blog: dataToBlogPb(restored),
+	}, nil
 }
 
-func (*server) ListBlog(_ *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer) error {
+func (s *server) ListBlog(req *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer) error {
 	fmt.Println("List blog request")
 
-	cur, err := collection.Find(context.Background(), primitive.D{{}})
+	if req.GetPageSize() < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Page size cannot be negative: %v", req.GetPageSize(),
+		)
+	}
+	filter, err := parseFilter(req.GetFilter())
 	if err != nil {
 		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Unknown internal error: %v", err),
+			codes.InvalidArgument,
+			"Cannot parse filter: %v", err,
 		)
 	}
-	defer cur.Close(context.Background()) // Should handle err
-	for cur.Next(context.Background()) {
-		data := &blogItem{}
-		err := cur.Decode(data)
-		if err != nil {
-			return status.Errorf(
-				codes.Internal,
-				fmt.Sprintf("Error while decoding data from MongoDB: %v", err),
-			)
-
+	order, err := parseOrderBy(req.GetOrderBy())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse order_by: %v", err,
+		)
+	}
+	if filter.Tags, err = normalizeTags(req.GetTags()); err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Invalid tags: %v", err,
+		)
+	}
+	filter.AnyTag = req.GetTagMatch() == blogpb.TagMatch_TAG_MATCH_ANY
+	if filter.Deleted == nil && !req.GetShowDeleted() {
+		live := false
+		filter.Deleted = &live
+	}
+	if filter.State == blogpb.Blog_STATE_UNSPECIFIED && !req.GetShowUnpublished() {
+		filter.State = blogpb.Blog_PUBLISHED
+	}
+	// only authors and admins see the blogs that are not published or in the trash
+	hidden := filter.State != blogpb.Blog_PUBLISHED || filter.Deleted == nil || *filter.Deleted
+	if hidden && filter.AuthorID == "" {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Unpublished and deleted blogs can only be listed along with an author_id filter",
+		)
+	}
+	if hidden {
+		if _, err := requireAuthor(stream.Context(), filter.AuthorID); err != nil {
+			return err
 		}
-		stream.Send(&blogpb.ListBlogResponse{Blog: dataToBlogPb(data)}) // Should handle err
 	}
-	if err := cur.Err(); err != nil {
+	query := fmt.Sprintf("%v|%v", filter, order)
+	token, err := decodePageToken(req.GetPageToken(), query)
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse page token: %v", err,
+		)
+	}
+	size := pageSize(req.GetPageSize())
+
+	// we ask for one more item than needed to know if there is a next page
+	items, err := s.store.List(stream.Context(), listQuery{
+		Filter: filter,
+		Order:  order,
+		After:  token.cursor(),
+		Limit:  size + 1,
+	})
+	if err != nil {
 		return status.Errorf(
 			codes.Internal,
-			fmt.Sprintf("Unknown internal error: %v", err),
+			"Unknown internal error: %v", err,
 		)
 	}
+	hasMore := len(items) > size
+	if hasMore {
+		items = items[:size]
+	}
+
+	for i, data := range items {
+		res := &blogpb.ListBlogResponse{Blog: dataToBlogPb(data)}
+		if hasMore && i == len(items)-1 {
+			last := cursorOf(data, order.sortKey())
+			res.NextPageToken = pageToken{LastID: last.ID, LastKey: last.Key, Query: query}.encode()
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func main() {
+	storeKind := flag.String("store", "mongo", "blog storage backend: mongo or memory")
+	mongoURI := flag.String("mongo-uri", "mongodb://localhost:27017", "MongoDB connection string, used with -store=mongo")
+	trashRetention := flag.Duration("trash-retention", 30*24*time.Hour, "how long deleted blogs stay in the trash before being purged")
+	purgeInterval := flag.Duration("purge-interval", time.Hour, "how often to look for deleted blogs to purge")
+	scheduleInterval := flag.Duration("schedule-interval", time.Minute, "how often to look for scheduled blogs to publish")
+	authFlags := auth.RegisterServerFlags(flag.CommandLine)
+	requestIDWindow := flag.Duration("request-id-window", defaultRequestIDWindow, "how long CreateBlog request IDs are remembered to detect retries")
+	flag.Parse()
+
 	// if we crash the go code, we get the file name and line number
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	fmt.Println("Connecting to MongoDB")
-	// connect to MongoDB
-	client, err := mongo.NewClient(options.Client().ApplyURI("mongodb://localhost:27017"))
-	if err != nil {
-		log.Fatal(err)
-	}
-	err = client.Connect(context.TODO())
-	if err != nil {
-		log.Fatal(err)
+	var store BlogStore
+	switch *storeKind {
+	case "mongo":
+		fmt.Println("Connecting to MongoDB")
+		// connect to MongoDB
+		mongoStore, err := newMongoStore(context.TODO(), *mongoURI)
+		if err != nil {
+			log.Fatal(err)
+		}
+		store = mongoStore
+	case "memory":
+		fmt.Println("Using in-memory storage")
+		store = newMemoryStore()
+	default:
+		log.Fatalf("Unknown store %q, expected mongo or memory", *storeKind)
 	}
 
 	fmt.Println("Blog Service Started")
-	collection = client.Database("mydb").Collection("blog")
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	go runPurger(backgroundCtx, store, *trashRetention, *purgeInterval)
+	go runScheduler(backgroundCtx, store, *scheduleInterval)
 
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	var opts []grpc.ServerOption
+	opts, err := auth.ServerOptions(backgroundCtx, authFlags, append(publicMethods, auth.ReflectionMethods...)...)
+	if err != nil {
+		log.Fatalf("Failed setting up authentication: %v", err)
+	}
+
 	s := grpc.NewServer(opts...)
-	blogpb.RegisterBlogServiceServer(s, &server{})
+	blogpb.RegisterBlogServiceServer(s, &server{store: store, requestIDWindow: *requestIDWindow})
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
 
@@ -256,10 +601,11 @@ func main() {
 
 	// Block until a signal is received
 	<-ch
-	// First we close the connection with MongoDB:
-	fmt.Println("Closing MongoDB Connection")
-	if err := client.Disconnect(context.TODO()); err != nil {
-		log.Fatalf("Error on disconnection with MongoDB : %v", err)
+	stopBackground()
+	// First we close the connection with the store:
+	fmt.Println("Closing the blog store")
+	if err := store.Close(context.TODO()); err != nil {
+		log.Fatalf("Error on closing the blog store : %v", err)
 	}
 
 	// Finally, we stop the server
@@ -0,0 +1,1623 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/simplesteph/grpc-go-course/auth"
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testSecret signs the tokens of the test callers
+var testSecret = []byte("blog-server-test-secret-0123456789")
+
+// newTestClient serves a blog server backed by store over an in-memory connection,
+// behind the same auth interceptors as main
+func newTestClient(t *testing.T, store BlogStore) blogpb.BlogServiceClient {
+	t.Helper()
+	verifier, err := auth.NewVerifier(testSecret, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(auth.NewInterceptor(verifier, publicMethods...).ServerOptions()...)
+	blogpb.RegisterBlogServiceServer(s, &server{store: store, requestIDWindow: time.Hour})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+	cc, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(dialer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return blogpb.NewBlogServiceClient(cc)
+}
+
+// as returns a context calling as the given author, "admin" having the admin role,
+// and an anonymous context for an empty author
+func as(authorID string) context.Context {
+	ctx := context.Background()
+	if authorID == "" {
+		return ctx
+	}
+	claims := &auth.Claims{Subject: authorID, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	if authorID == adminRole {
+		claims.Roles = []string{adminRole}
+	}
+	token, err := auth.Sign(claims, testSecret)
+	if err != nil {
+		panic(err)
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func createBlog(t *testing.T, c blogpb.BlogServiceClient, authorID string, blog *blogpb.Blog) *blogpb.Blog {
+	t.Helper()
+	res, err := c.CreateBlog(as(authorID), &blogpb.CreateBlogRequest{Blog: blog})
+	if err != nil {
+		t.Fatalf("CreateBlog(%v): %v", blog, err)
+	}
+	return res.GetBlog()
+}
+
+// listTitles lists the titles of a single page, along with the token of the next one
+func listTitles(ctx context.Context, c blogpb.BlogServiceClient, req *blogpb.ListBlogRequest) ([]string, string, error) {
+	stream, err := c.ListBlog(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	var titles []string
+	var next string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return titles, next, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		titles = append(titles, res.GetBlog().GetTitle())
+		if res.GetNextPageToken() != "" {
+			next = res.GetNextPageToken()
+		}
+	}
+}
+
+func mask(paths ...string) *field_mask.FieldMask {
+	return &field_mask.FieldMask{Paths: paths}
+}
+
+func TestCRUD(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "Hello", Content: "World"})
+	if blog.GetId() == "" || blog.GetAuthorId() != "alice" || blog.GetVersion() != 1 || blog.GetCreateTime() == nil {
+		t.Fatalf("CreateBlog() = %v", blog)
+	}
+
+	read, err := c.ReadBlog(as(""), &blogpb.ReadBlogRequest{BlogId: blog.GetId()})
+	if err != nil || !proto.Equal(read.GetBlog(), blog) {
+		t.Fatalf("ReadBlog() = %v, %v, want %v", read, err, blog)
+	}
+
+	updated, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{
+		Blog: &blogpb.Blog{Id: blog.GetId(), Title: "Hello again", Content: "World", Version: 1},
+	})
+	if err != nil || updated.GetBlog().GetTitle() != "Hello again" || updated.GetBlog().GetVersion() != 2 {
+		t.Fatalf("UpdateBlog() = %v, %v", updated, err)
+	}
+	if !proto.Equal(updated.GetBlog().GetCreateTime(), blog.GetCreateTime()) || updated.GetBlog().GetUpdateTime() == nil {
+		t.Errorf("UpdateBlog() times = %v, %v, want the creation time kept", updated.GetBlog().GetCreateTime(), updated.GetBlog().GetUpdateTime())
+	}
+
+	if titles, _, err := listTitles(as(""), c, &blogpb.ListBlogRequest{}); err != nil || len(titles) != 1 || titles[0] != "Hello again" {
+		t.Fatalf("ListBlog() = %v, %v, want [Hello again]", titles, err)
+	}
+
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: blog.GetId()}); err != nil {
+		t.Fatalf("DeleteBlog() = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		blogID string
+		want   codes.Code
+	}{
+		{"deleted", blog.GetId(), codes.NotFound},
+		{"unknown", "5bdc29e661b75adcac496cf4", codes.NotFound},
+		{"malformed", "not-an-id", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.ReadBlog(as("alice"), &blogpb.ReadBlogRequest{BlogId: tt.blogID}); status.Code(err) != tt.want {
+				t.Errorf("ReadBlog() = %v, want %v", err, tt.want)
+			}
+			if _, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: tt.blogID, Version: 1}}); status.Code(err) != tt.want {
+				t.Errorf("UpdateBlog() = %v, want %v", err, tt.want)
+			}
+			if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: tt.blogID}); status.Code(err) != tt.want {
+				t.Errorf("DeleteBlog() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListBlogPagination(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	for _, title := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: title})
+	}
+
+	var pages [][]string
+	token := ""
+	for {
+		titles, next, err := listTitles(as(""), c, &blogpb.ListBlogRequest{PageSize: 3, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListBlog(page %v) = %v", len(pages)+1, err)
+		}
+		pages = append(pages, titles)
+		if len(pages) == 1 {
+			// blogs created between two pages show up on a later page
+			createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "h"})
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	want := [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h"}}
+	if !reflect.DeepEqual(pages, want) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+
+	tests := []struct {
+		name string
+		req  *blogpb.ListBlogRequest
+	}{
+		{"malformed token", &blogpb.ListBlogRequest{PageToken: "!!"}},
+		{"negative page size", &blogpb.ListBlogRequest{PageSize: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := listTitles(as(""), c, tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("ListBlog() = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestListBlogFilterAndOrder(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	blogs := []struct{ authorID, title string }{
+		{"x", "banana"}, {"y", "apple"}, {"x", "apricot"}, {"x", "cherry"}, {"x", "apple"}, {"y", "star*"},
+	}
+	for _, b := range blogs {
+		createBlog(t, c, adminRole, &blogpb.Blog{State: blogpb.Blog_PUBLISHED, AuthorId: b.authorID, Title: b.title})
+	}
+
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy string
+		want    []string
+	}{
+		{"author", `author_id = "x"`, "title", []string{"apple", "apricot", "banana", "cherry"}},
+		{"title prefix", `title = "ap*"`, "title desc", []string{"apricot", "apple", "apple"}},
+		{"and", `author_id = y AND title = apple`, "", []string{"apple"}},
+		{"creation order", `author_id = x`, "", []string{"banana", "apricot", "cherry", "apple"}},
+		{"reverse creation order", `author_id = x`, "create_time desc", []string{"apple", "cherry", "apricot", "banana"}},
+		{"literal star", `title = star\*`, "", []string{"star*"}},
+		{"star prefix", `title = "star*"`, "", []string{"star*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a page size of 1 also checks the page tokens of each order
+			var got []string
+			token := ""
+			for {
+				titles, next, err := listTitles(as(""), c, &blogpb.ListBlogRequest{Filter: tt.filter, OrderBy: tt.orderBy, PageSize: 1, PageToken: token})
+				if err != nil {
+					t.Fatalf("ListBlog() = %v", err)
+				}
+				got = append(got, titles...)
+				if next == "" {
+					break
+				}
+				token = next
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListBlog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, titleToken, err := listTitles(as(""), c, &blogpb.ListBlogRequest{PageSize: 2, OrderBy: "title"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := []struct {
+		name string
+		req  *blogpb.ListBlogRequest
+	}{
+		{"unknown field", &blogpb.ListBlogRequest{Filter: `content = "x"`}},
+		{"unknown operator", &blogpb.ListBlogRequest{Filter: `author_id > 3`}},
+		{"unterminated string", &blogpb.ListBlogRequest{Filter: `author_id = "x`}},
+		{"or", &blogpb.ListBlogRequest{Filter: `author_id = x OR title = y`}},
+		{"unpublished without author", &blogpb.ListBlogRequest{ShowUnpublished: true}},
+		{"drafts without author", &blogpb.ListBlogRequest{Filter: `state = draft`}},
+		{"empty prefix", &blogpb.ListBlogRequest{Filter: `title = "*"`}},
+		{"unknown order", &blogpb.ListBlogRequest{OrderBy: "content"}},
+		{"unknown direction", &blogpb.ListBlogRequest{OrderBy: "title sideways"}},
+		{"token of another order", &blogpb.ListBlogRequest{PageSize: 2, PageToken: titleToken, OrderBy: "id"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := listTitles(as(""), c, tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("ListBlog() = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestUpdateBlog(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  *blogpb.Blog
+		mask    *field_mask.FieldMask
+		want    *blogpb.Blog
+		wantErr codes.Code
+	}{
+		{
+			name:   "implicit mask keeps the author and tags",
+			update: &blogpb.Blog{Title: "new", Content: "new content", Version: 1},
+			want:   &blogpb.Blog{AuthorId: "alice", Title: "new", Content: "new content", Tags: []string{"go"}, Version: 2},
+		},
+		{
+			name:   "title only",
+			update: &blogpb.Blog{Title: "new", Version: 1},
+			mask:   mask("title"),
+			want:   &blogpb.Blog{AuthorId: "alice", Title: "new", Content: "content", Tags: []string{"go"}, Version: 2},
+		},
+		{
+			name:   "tags are normalized",
+			update: &blogpb.Blog{Tags: []string{" Rust", "go", "rust"}, Version: 1},
+			mask:   mask("tags"),
+			want:   &blogpb.Blog{AuthorId: "alice", Title: "title", Content: "content", Tags: []string{"rust", "go"}, Version: 2},
+		},
+		{
+			name:   "wildcard clears what is unset",
+			update: &blogpb.Blog{Title: "new", Version: 1},
+			mask:   mask("*"),
+			want:   &blogpb.Blog{AuthorId: "alice", Title: "new", Version: 2},
+		},
+		{
+			name:    "missing version",
+			update:  &blogpb.Blog{Title: "new"},
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			name:    "stale version",
+			update:  &blogpb.Blog{Title: "new", Version: 2},
+			wantErr: codes.Aborted,
+		},
+		{
+			name:    "empty tag",
+			update:  &blogpb.Blog{Tags: []string{"go", " "}, Version: 1},
+			mask:    mask("tags"),
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			name:    "read-only field",
+			update:  &blogpb.Blog{Version: 1},
+			mask:    mask("id"),
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			name:    "unknown field",
+			update:  &blogpb.Blog{Version: 1},
+			mask:    mask("nope"),
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			name:    "wildcard along with other paths",
+			update:  &blogpb.Blog{Version: 1},
+			mask:    mask("*", "title"),
+			wantErr: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, newMemoryStore())
+			blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "title", Content: "content", Tags: []string{"go"}})
+			tt.update.Id = blog.GetId()
+			req := &blogpb.UpdateBlogRequest{Blog: tt.update, UpdateMask: tt.mask}
+			sent := proto.Clone(req)
+
+			res, err := c.UpdateBlog(as("alice"), req)
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("UpdateBlog() = %v, want %v", err, tt.wantErr)
+			}
+			if !proto.Equal(req, sent) {
+				t.Errorf("UpdateBlog() changed the request to %v", req)
+			}
+			if err != nil {
+				return
+			}
+			got := res.GetBlog()
+			fields := &blogpb.Blog{AuthorId: got.AuthorId, Title: got.Title, Content: got.Content, Tags: got.Tags, Version: got.Version}
+			if !proto.Equal(fields, tt.want) {
+				t.Errorf("UpdateBlog() = %v, want %v", fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteBlogVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		want    codes.Code
+	}{
+		{"any version", 0, codes.OK},
+		{"current version", 1, codes.OK},
+		{"stale version", 2, codes.Aborted},
+		{"negative version", -1, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, newMemoryStore())
+			blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "title"})
+			if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: blog.GetId(), Version: tt.version}); status.Code(err) != tt.want {
+				t.Errorf("DeleteBlog() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteAndPurge(t *testing.T) {
+	ctx := as("alice")
+	store := newMemoryStore()
+	c := newTestClient(t, store)
+	kept := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "kept"})
+	trashed := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "trashed"})
+
+	if _, err := c.UndeleteBlog(ctx, &blogpb.UndeleteBlogRequest{BlogId: trashed.GetId()}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("UndeleteBlog(live blog) = %v, want FailedPrecondition", err)
+	}
+	if _, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: trashed.GetId(), Version: 1}); err != nil {
+		t.Fatalf("DeleteBlog() = %v", err)
+	}
+
+	lists := []struct {
+		name    string
+		caller  string
+		req     *blogpb.ListBlogRequest
+		want    []string
+		wantErr codes.Code
+	}{
+		{"live blogs", "", &blogpb.ListBlogRequest{}, []string{"kept"}, codes.OK},
+		{"trash", "alice", &blogpb.ListBlogRequest{Filter: `author_id = alice AND deleted = true`}, []string{"trashed"}, codes.OK},
+		{"all blogs", "alice", &blogpb.ListBlogRequest{Filter: `author_id = alice`, ShowDeleted: true}, []string{"kept", "trashed"}, codes.OK},
+		{"trash without author", adminRole, &blogpb.ListBlogRequest{ShowDeleted: true}, nil, codes.InvalidArgument},
+		{"anonymous trash", "", &blogpb.ListBlogRequest{Filter: `author_id = alice AND deleted = true`}, nil, codes.Unauthenticated},
+		{"trash of another author", "bob", &blogpb.ListBlogRequest{Filter: `author_id = alice AND deleted = true`}, nil, codes.PermissionDenied},
+	}
+	for _, tt := range lists {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := listTitles(as(tt.caller), c, tt.req)
+			if status.Code(err) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListBlog() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := c.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: trashed.GetId(), Title: "x", Version: 2}}); status.Code(err) != codes.NotFound {
+		t.Fatalf("UpdateBlog(deleted blog) = %v, want NotFound", err)
+	}
+	if _, err := c.UndeleteBlog(ctx, &blogpb.UndeleteBlogRequest{BlogId: trashed.GetId(), Version: 1}); status.Code(err) != codes.Aborted {
+		t.Fatalf("UndeleteBlog(stale version) = %v, want Aborted", err)
+	}
+	restored, err := c.UndeleteBlog(ctx, &blogpb.UndeleteBlogRequest{BlogId: trashed.GetId(), Version: 2})
+	if err != nil || restored.GetBlog().GetDeleteTime() != nil || restored.GetBlog().GetVersion() != 3 {
+		t.Fatalf("UndeleteBlog() = %v, %v", restored, err)
+	}
+
+	if _, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: trashed.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := store.PurgeDeleted(ctx, time.Now().Add(-time.Hour)); n != 0 || err != nil {
+		t.Fatalf("PurgeDeleted(an hour ago) = %v, %v, want 0", n, err)
+	}
+	if n, err := store.PurgeDeleted(ctx, time.Now().Add(time.Hour)); n != 1 || err != nil {
+		t.Fatalf("PurgeDeleted(in an hour) = %v, %v, want 1", n, err)
+	}
+	got, _, err := listTitles(ctx, c, &blogpb.ListBlogRequest{Filter: `author_id = alice`, ShowDeleted: true})
+	if err != nil || !reflect.DeepEqual(got, []string{"kept"}) {
+		t.Errorf("ListBlog() after purge = %v, %v, want [%v]", got, err, kept.GetTitle())
+	}
+}
+
+func TestBlogRevisions(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "v1", Content: "first"})
+	id := blog.GetId()
+	for version, title := range []string{"v2", "v3"} {
+		if _, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, Title: title, Version: int64(version + 1)}, UpdateMask: mask("title")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a page size of 1 also checks the page tokens
+	var titles []string
+	token := ""
+	for {
+		stream, err := c.ListBlogRevisions(as(""), &blogpb.ListBlogRevisionsRequest{BlogId: id, PageSize: 1, PageToken: token})
+		if err != nil {
+			t.Fatal(err)
+		}
+		token = ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("ListBlogRevisions() = %v", err)
+			}
+			titles = append(titles, res.GetRevision().GetTitle())
+			token = res.GetNextPageToken()
+		}
+		if token == "" {
+			break
+		}
+	}
+	if want := []string{"v2", "v1"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("ListBlogRevisions() = %v, want %v", titles, want)
+	}
+
+	tests := []struct {
+		name    string
+		blogID  string
+		version int64
+		want    *blogpb.BlogRevision
+		wantErr codes.Code
+	}{
+		{"first version", id, 1, &blogpb.BlogRevision{BlogId: id, Version: 1, AuthorId: "alice", Title: "v1", Content: "first"}, codes.OK},
+		{"current version", id, 3, nil, codes.NotFound},
+		{"unknown blog", "5bdc29e661b75adcac496cf4", 1, nil, codes.NotFound},
+		{"malformed ID", "not-an-id", 1, nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.GetBlogRevision(as(""), &blogpb.GetBlogRevisionRequest{BlogId: tt.blogID, Version: tt.version})
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("GetBlogRevision() = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := proto.Clone(res.GetRevision()).(*blogpb.BlogRevision)
+			got.UpdateTime = nil
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("GetBlogRevision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDraftRevisionsAreHidden(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	blog := createBlog(t, c, "alice", &blogpb.Blog{Title: "draft", Content: "not ready"})
+	id := blog.GetId()
+	if _, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, Title: "ready", Version: 1}, UpdateMask: mask("title")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.PublishBlog(as("alice"), &blogpb.PublishBlogRequest{BlogId: id, Version: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, Title: "edited", Version: 3}, UpdateMask: mask("title")}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		caller string
+		want   []int64
+	}{
+		{"alice", []int64{3, 1}},
+		{adminRole, []int64{3, 1}},
+		{"bob", []int64{3}},
+		{"", []int64{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caller, func(t *testing.T) {
+			stream, err := c.ListBlogRevisions(as(tt.caller), &blogpb.ListBlogRevisionsRequest{BlogId: id})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var versions []int64
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ListBlogRevisions() = %v", err)
+				}
+				versions = append(versions, res.GetRevision().GetVersion())
+			}
+			if !reflect.DeepEqual(versions, tt.want) {
+				t.Errorf("ListBlogRevisions() = %v, want %v", versions, tt.want)
+			}
+
+			_, err = c.GetBlogRevision(as(tt.caller), &blogpb.GetBlogRevisionRequest{BlogId: id, Version: 1})
+			if want := len(tt.want) == 2; (err == nil) != want {
+				t.Errorf("GetBlogRevision(draft) = %v, want it found: %v", err, want)
+			}
+			if err != nil && status.Code(err) != codes.NotFound {
+				t.Errorf("GetBlogRevision(draft) = %v, want NotFound", err)
+			}
+		})
+	}
+}
+
+func TestRestoreBlogRevision(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "v1", Content: "first", Tags: []string{"go"}})
+	id := blog.GetId()
+	if _, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, Title: "v2", Content: "second", Version: 1}, UpdateMask: mask("title", "content", "tags")}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		caller string
+		req    *blogpb.RestoreBlogRevisionRequest
+		want   codes.Code
+	}{
+		{"stale blog version", "alice", &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 1, BlogVersion: 1}, codes.Aborted},
+		{"negative blog version", "alice", &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 1, BlogVersion: -1}, codes.InvalidArgument},
+		{"unknown revision", "alice", &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 9}, codes.NotFound},
+		{"current version", "alice", &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 2}, codes.NotFound},
+		{"another author", "bob", &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 1}, codes.PermissionDenied},
+		{"anonymous", "", &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 1}, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.RestoreBlogRevision(as(tt.caller), tt.req); status.Code(err) != tt.want {
+				t.Errorf("RestoreBlogRevision() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// restoring is a new version, keeping the replaced one as a revision
+	res, err := c.RestoreBlogRevision(as("alice"), &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 1, BlogVersion: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := res.GetBlog()
+	if restored.GetVersion() != 3 || restored.GetTitle() != "v1" || restored.GetContent() != "first" || !reflect.DeepEqual(restored.GetTags(), []string{"go"}) {
+		t.Errorf("RestoreBlogRevision() = %v, want version 3 with the content of version 1", restored)
+	}
+	rev, err := c.GetBlogRevision(as(""), &blogpb.GetBlogRevisionRequest{BlogId: id, Version: 2})
+	if err != nil || rev.GetRevision().GetTitle() != "v2" {
+		t.Errorf("GetBlogRevision(2) = %v, %v, want the replaced version", rev, err)
+	}
+
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RestoreBlogRevision(as("alice"), &blogpb.RestoreBlogRevisionRequest{BlogId: id, Version: 1}); status.Code(err) != codes.NotFound {
+		t.Errorf("RestoreBlogRevision() of a deleted blog = %v, want NotFound", err)
+	}
+}
+
+// watchUntilSubscribed starts watching, creating blogs titled "ready" until one of them reaches the watch
+// so that it is known to be subscribed, and returns the resume token of that first event
+func watchUntilSubscribed(t *testing.T, ctx context.Context, c blogpb.BlogServiceClient, req *blogpb.WatchBlogsRequest) (blogpb.BlogService_WatchBlogsClient, string) {
+	t.Helper()
+	stream, err := c.WatchBlogs(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorID := req.GetAuthorId()
+	if authorID == "" {
+		authorID = "alice"
+	}
+	subscribed := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			c.CreateBlog(as(authorID), &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "ready"}})
+			select {
+			case <-subscribed:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(subscribed)
+	<-done
+	return stream, res.GetEvent().GetResumeToken()
+}
+
+type watchEvent struct {
+	Type  blogpb.BlogEvent_Type
+	ID    string
+	Title string
+}
+
+// recvEvents receives n events, skipping the blogs created by watchUntilSubscribed
+func recvEvents(t *testing.T, stream blogpb.BlogService_WatchBlogsClient, n int) []watchEvent {
+	t.Helper()
+	var got []watchEvent
+	for len(got) < n {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		blog := res.GetEvent().GetBlog()
+		if blog.GetTitle() == "ready" {
+			continue
+		}
+		got = append(got, watchEvent{res.GetEvent().GetType(), blog.GetId(), blog.GetTitle()})
+	}
+	return got
+}
+
+func TestWatchBlogs(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	ctx, cancel := context.WithCancel(as("alice"))
+	defer cancel()
+	all, resumeToken := watchUntilSubscribed(t, ctx, c, &blogpb.WatchBlogsRequest{})
+	bobs, _ := watchUntilSubscribed(t, ctx, c, &blogpb.WatchBlogsRequest{AuthorId: "bob"})
+
+	blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "first"})
+	if _, err := c.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: blog.GetId(), Title: "second", Version: 1}, UpdateMask: mask("title")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: blog.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	other := createBlog(t, c, "bob", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "bob's"})
+
+	want := []watchEvent{
+		{blogpb.BlogEvent_CREATED, blog.GetId(), "first"},
+		{blogpb.BlogEvent_UPDATED, blog.GetId(), "second"},
+		{blogpb.BlogEvent_DELETED, blog.GetId(), "second"},
+		{blogpb.BlogEvent_CREATED, other.GetId(), "bob's"},
+	}
+	if got := recvEvents(t, all, len(want)); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if got := recvEvents(t, bobs, 1); !reflect.DeepEqual(got, want[3:]) {
+		t.Errorf("events of bob = %v, want %v", got, want[3:])
+	}
+
+	// resuming after the first event replays the ones that followed
+	resumed, err := c.WatchBlogs(ctx, &blogpb.WatchBlogsRequest{ResumeToken: resumeToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := recvEvents(t, resumed, len(want)); !reflect.DeepEqual(got, want) {
+		t.Errorf("resumed events = %v, want %v", got, want)
+	}
+
+	epoch := resumeToken[:strings.LastIndex(resumeToken, ".")]
+	tokens := []struct {
+		token string
+		want  codes.Code
+	}{
+		{"nope", codes.InvalidArgument},
+		{"1", codes.InvalidArgument},
+		{".1", codes.InvalidArgument},
+		{epoch + ".-1", codes.InvalidArgument},
+		{epoch + ".1000000", codes.InvalidArgument},
+		// a token of another server, or of this one before it restarted
+		{primitive.NewObjectID().Hex() + ".1", codes.OutOfRange},
+	}
+	for _, tt := range tokens {
+		stream, err := c.WatchBlogs(ctx, &blogpb.WatchBlogsRequest{ResumeToken: tt.token})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Recv(); status.Code(err) != tt.want {
+			t.Errorf("WatchBlogs(resume token %q) = %v, want %v", tt.token, err, tt.want)
+		}
+	}
+}
+
+func TestWatchHidesUnpublishedBlogs(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	ctx, cancel := context.WithCancel(as(""))
+	defer cancel()
+	stream, _ := watchUntilSubscribed(t, ctx, c, &blogpb.WatchBlogsRequest{})
+
+	draft := createBlog(t, c, "alice", &blogpb.Blog{Title: "draft"})
+	if _, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: draft.GetId(), Title: "still a draft", Version: 1}, UpdateMask: mask("title")}); err != nil {
+		t.Fatal(err)
+	}
+	published := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "published"})
+	if _, err := c.UnpublishBlog(as("alice"), &blogpb.UnpublishBlogRequest{BlogId: published.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: draft.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	last := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "last"})
+
+	// anonymous watchers never see the draft, and only learn that the unpublished blog was removed
+	want := []watchEvent{
+		{blogpb.BlogEvent_CREATED, published.GetId(), "published"},
+		{blogpb.BlogEvent_REMOVED, published.GetId(), ""},
+		{blogpb.BlogEvent_CREATED, last.GetId(), "last"},
+	}
+	if got := recvEvents(t, stream, len(want)); !reflect.DeepEqual(got, want) {
+		t.Errorf("anonymous events = %v, want %v", got, want)
+	}
+}
+
+func TestUnpublishedBlogsAreHidden(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	draft := createBlog(t, c, "alice", &blogpb.Blog{Title: "draft", Content: "secret"})
+	id := draft.GetId()
+	// the update keeps version 1 as a revision
+	if _, err := c.UpdateBlog(as("alice"), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, Title: "still a draft", Version: 1}, UpdateMask: mask("title")}); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"ReadBlog", func(ctx context.Context) error {
+			_, err := c.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: id})
+			return err
+		}},
+		{"GetBlogRevision", func(ctx context.Context) error {
+			_, err := c.GetBlogRevision(ctx, &blogpb.GetBlogRevisionRequest{BlogId: id, Version: 1})
+			return err
+		}},
+		{"ListBlogRevisions", func(ctx context.Context) error {
+			stream, err := c.ListBlogRevisions(ctx, &blogpb.ListBlogRevisionsRequest{BlogId: id})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}},
+		{"CreateComment", func(ctx context.Context) error {
+			_, err := c.CreateComment(ctx, &blogpb.CreateCommentRequest{Comment: &blogpb.Comment{BlogId: id, Content: "hi"}})
+			return err
+		}},
+	}
+	callers := []struct {
+		name     string
+		authorID string
+		want     codes.Code
+	}{
+		{"anonymous", "", codes.NotFound},
+		{"another author", "bob", codes.NotFound},
+		{"author", "alice", codes.OK},
+		{"admin", adminRole, codes.OK},
+	}
+	for _, caller := range callers {
+		for _, call := range calls {
+			t.Run(caller.name+"/"+call.name, func(t *testing.T) {
+				if err := call.call(as(caller.authorID)); status.Code(err) != caller.want {
+					t.Errorf("got %v, want %v", err, caller.want)
+				}
+			})
+		}
+	}
+}
+
+func TestCreateBlogRequestID(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	create := func(authorID, requestID string, blog *blogpb.Blog) (*blogpb.Blog, error) {
+		res, err := c.CreateBlog(as(authorID), &blogpb.CreateBlogRequest{RequestId: requestID, Blog: blog})
+		return res.GetBlog(), err
+	}
+
+	first, err := create("alice", "abc", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	retry, err := create("alice", "abc", &blogpb.Blog{Title: "first"})
+	if err != nil || retry.GetId() != first.GetId() {
+		t.Fatalf("retried CreateBlog() = %v, %v, want blog %v", retry, err, first.GetId())
+	}
+	other, err := create("alice", "xyz", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "other"})
+	if err != nil || other.GetId() == first.GetId() {
+		t.Fatalf("CreateBlog() with another request ID = %v, %v, want a new blog", other, err)
+	}
+	if _, err := create("alice", strings.Repeat("x", maxRequestIDLength+1), &blogpb.Blog{Title: "long"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateBlog() with a long request ID = %v, want InvalidArgument", err)
+	}
+	titles, _, err := listTitles(as(""), c, &blogpb.ListBlogRequest{})
+	if want := []string{"first", "other"}; err != nil || !reflect.DeepEqual(titles, want) {
+		t.Errorf("ListBlog() = %v, %v, want %v", titles, err, want)
+	}
+
+	// request IDs are scoped to the caller, so bob cannot read alice's blog through hers
+	bobs, err := create("bob", "abc", &blogpb.Blog{Title: "bob"})
+	if err != nil || bobs.GetId() == first.GetId() || bobs.GetAuthorId() != "bob" {
+		t.Fatalf("CreateBlog() by bob = %v, %v, want a new blog", bobs, err)
+	}
+
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: first.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := create("alice", "abc", &blogpb.Blog{Title: "first"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateBlog() retried after a delete = %v, want FailedPrecondition", err)
+	}
+
+	given, err := create("carol", "xyz", &blogpb.Blog{Title: "carol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateBlog(as(adminRole), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: given.GetId(), AuthorId: "bob", Version: 1}, UpdateMask: mask("author_id")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := create("carol", "xyz", &blogpb.Blog{Title: "carol"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateBlog() retried after giving the blog away = %v, want AlreadyExists", err)
+	}
+}
+
+// searchTitles returns the titles of every result of a search, asking for pages of one result to also check the page tokens
+func searchTitles(c blogpb.BlogServiceClient, query string) ([]string, error) {
+	var titles []string
+	token := ""
+	for {
+		stream, err := c.SearchBlogs(as(""), &blogpb.SearchBlogsRequest{Query: query, PageSize: 1, PageToken: token})
+		if err != nil {
+			return nil, err
+		}
+		token = ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			titles = append(titles, res.GetBlog().GetTitle())
+			token = res.GetNextPageToken()
+		}
+		if token == "" {
+			return titles, nil
+		}
+	}
+}
+
+func TestSearchBlogs(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "Go", Content: "go channels and go routines"})
+	createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "Intro", Content: "learning go and rust"})
+	createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "Rust", Content: "ownership"})
+	createBlog(t, c, "alice", &blogpb.Blog{Title: "Draft", Content: "go"})
+	gone := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "Gone", Content: "go"})
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: gone.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"title and repeated words rank first", "go", []string{"Go", "Intro"}},
+		{"any word", "ownership routines", []string{"Go", "Rust"}},
+		{"excluded word", "go -rust", []string{"Go"}},
+		{"phrase", `go "learning go"`, []string{"Intro"}},
+		{"excluded phrase", `go -"go channels"`, []string{"Intro"}},
+		{"phrase across words only", `"go rust"`, nil},
+		{"no match", "python", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := searchTitles(c, tt.query)
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchBlogs(%q) = %v, %v, want %v", tt.query, got, err, tt.want)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name string
+		req  *blogpb.SearchBlogsRequest
+		// part of the error message
+		want string
+	}{
+		{"no words", &blogpb.SearchBlogsRequest{Query: "  "}, "at least one word"},
+		{"only excluded words", &blogpb.SearchBlogsRequest{Query: "-go -rust"}, "at least one word"},
+		{"too long", &blogpb.SearchBlogsRequest{Query: strings.Repeat("go ", 100)}, "longer than"},
+		{"too long without words", &blogpb.SearchBlogsRequest{Query: strings.Repeat("-go ", 100)}, "longer than"},
+		{"negative page size", &blogpb.SearchBlogsRequest{Query: "go", PageSize: -1}, "Page size"},
+		{"malformed page token", &blogpb.SearchBlogsRequest{Query: "go", PageToken: "nope"}, "page token"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.SearchBlogs(as(""), tt.req)
+			if err == nil {
+				_, err = stream.Recv()
+			}
+			if st := status.Convert(err); st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), tt.want) {
+				t.Errorf("SearchBlogs() = %v, want InvalidArgument containing %q", err, tt.want)
+			}
+		})
+	}
+
+	// a page token only goes with the query it was given for
+	stream, err := c.SearchBlogs(as(""), &blogpb.SearchBlogsRequest{Query: "go", PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := stream.Recv()
+	if err != nil || res.GetNextPageToken() == "" {
+		t.Fatalf("SearchBlogs() = %v, %v, want a next page", res, err)
+	}
+	other, err := c.SearchBlogs(as(""), &blogpb.SearchBlogsRequest{Query: "rust", PageSize: 1, PageToken: res.GetNextPageToken()})
+	if err == nil {
+		_, err = other.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SearchBlogs() with the page token of another query = %v, want InvalidArgument", err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	ctx := as("alice")
+	c := newTestClient(t, newMemoryStore())
+	blogs := []struct {
+		title string
+		tags  []string
+	}{
+		{"go and grpc", []string{"go", "grpc"}},
+		{"go", []string{"Go"}},
+		{"rust", []string{"rust"}},
+		{"deleted", []string{"go", "rust"}},
+	}
+	for _, b := range blogs {
+		blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: b.title, Tags: b.tags})
+		if b.title == "deleted" {
+			if _, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: blog.GetId()}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	lists := []struct {
+		name  string
+		tags  []string
+		match blogpb.TagMatch
+		want  []string
+	}{
+		{"all tags", []string{"GO", "grpc"}, blogpb.TagMatch_TAG_MATCH_ALL, []string{"go and grpc"}},
+		{"any tag", []string{"grpc", "rust"}, blogpb.TagMatch_TAG_MATCH_ANY, []string{"go and grpc", "rust"}},
+		{"unused tag", []string{"java"}, blogpb.TagMatch_TAG_MATCH_ANY, nil},
+	}
+	for _, tt := range lists {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := listTitles(ctx, c, &blogpb.ListBlogRequest{Tags: tt.tags, TagMatch: tt.match})
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListBlog() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	// drafts and scheduled blogs are only counted once they are published, even for their author or an admin
+	createBlog(t, c, "alice", &blogpb.Blog{Title: "draft", Tags: []string{"go", "java"}})
+	publishTime, err := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_SCHEDULED, Title: "scheduled", Tags: []string{"rust", "java"}, PublishTime: publishTime})
+
+	want := []*blogpb.TagCount{{Tag: "go", Count: 2}, {Tag: "grpc", Count: 1}, {Tag: "rust", Count: 1}}
+	for _, caller := range []string{"alice", adminRole, ""} {
+		res, err := c.ListTags(as(caller), &blogpb.ListTagsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.GetTags()) != len(want) {
+			t.Fatalf("ListTags() as %q = %v, want %v", caller, res.GetTags(), want)
+		}
+		for i := range want {
+			if !proto.Equal(res.GetTags()[i], want[i]) {
+				t.Errorf("ListTags() as %q = %v, want %v", caller, res.GetTags(), want)
+				break
+			}
+		}
+	}
+}
+
+func TestStateTransitions(t *testing.T) {
+	inAnHour := time.Now().Add(time.Hour)
+	publishTime, err := ptypes.TimestampProto(inAnHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type step struct {
+		name    string
+		call    func(c blogpb.BlogServiceClient, id string) (*blogpb.Blog, error)
+		want    blogpb.Blog_State
+		wantErr codes.Code
+	}
+	publish := func(req *blogpb.PublishBlogRequest) func(blogpb.BlogServiceClient, string) (*blogpb.Blog, error) {
+		return func(c blogpb.BlogServiceClient, id string) (*blogpb.Blog, error) {
+			req.BlogId = id
+			res, err := c.PublishBlog(as("alice"), req)
+			return res.GetBlog(), err
+		}
+	}
+	unpublish := func(req *blogpb.UnpublishBlogRequest) func(blogpb.BlogServiceClient, string) (*blogpb.Blog, error) {
+		return func(c blogpb.BlogServiceClient, id string) (*blogpb.Blog, error) {
+			req.BlogId = id
+			res, err := c.UnpublishBlog(as("alice"), req)
+			return res.GetBlog(), err
+		}
+	}
+	steps := []step{
+		{"publish a draft", publish(&blogpb.PublishBlogRequest{Version: 1}), blogpb.Blog_PUBLISHED, codes.OK},
+		{"publish again", publish(&blogpb.PublishBlogRequest{}), 0, codes.FailedPrecondition},
+		{"archive", unpublish(&blogpb.UnpublishBlogRequest{Archive: true, Version: 2}), blogpb.Blog_ARCHIVED, codes.OK},
+		{"schedule", publish(&blogpb.PublishBlogRequest{PublishTime: publishTime}), blogpb.Blog_SCHEDULED, codes.OK},
+		{"unschedule", unpublish(&blogpb.UnpublishBlogRequest{}), blogpb.Blog_DRAFT, codes.OK},
+		{"unpublish a draft", unpublish(&blogpb.UnpublishBlogRequest{}), 0, codes.FailedPrecondition},
+		{"publish with a stale version", publish(&blogpb.PublishBlogRequest{Version: 1}), 0, codes.Aborted},
+	}
+
+	c := newTestClient(t, newMemoryStore())
+	res, err := c.CreateBlog(as("alice"), &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Title: "draft"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blog := res.GetBlog()
+	if blog.GetState() != blogpb.Blog_DRAFT || blog.GetPublishTime() != nil {
+		t.Fatalf("CreateBlog() = %v, want an unpublished draft", blog)
+	}
+	for _, s := range steps {
+		got, err := s.call(c, blog.GetId())
+		if status.Code(err) != s.wantErr {
+			t.Fatalf("%v: %v, want %v", s.name, err, s.wantErr)
+		}
+		if err == nil && got.GetState() != s.want {
+			t.Fatalf("%v: state %v, want %v", s.name, got.GetState(), s.want)
+		}
+	}
+
+	invalid := []struct {
+		name string
+		blog *blogpb.Blog
+	}{
+		{"scheduled without a publish time", &blogpb.Blog{State: blogpb.Blog_SCHEDULED}},
+		{"archived", &blogpb.Blog{State: blogpb.Blog_ARCHIVED}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.CreateBlog(as("alice"), &blogpb.CreateBlogRequest{Blog: tt.blog}); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("CreateBlog() = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestScheduledPublishing(t *testing.T) {
+	ctx := as("alice")
+	store := newMemoryStore()
+	c := newTestClient(t, store)
+	inAnHour := time.Now().Add(time.Hour)
+	publishTime, err := ptypes.TimestampProto(inAnHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_SCHEDULED, Title: "later", PublishTime: publishTime})
+	createBlog(t, c, "alice", &blogpb.Blog{Title: "draft"})
+
+	lists := []struct {
+		req  *blogpb.ListBlogRequest
+		want []string
+	}{
+		{&blogpb.ListBlogRequest{}, nil},
+		{&blogpb.ListBlogRequest{Filter: `author_id = alice`, ShowUnpublished: true}, []string{"later", "draft"}},
+		{&blogpb.ListBlogRequest{Filter: `author_id = alice AND state = scheduled`}, []string{"later"}},
+	}
+	for _, l := range lists {
+		if got, _, err := listTitles(ctx, c, l.req); err != nil || !reflect.DeepEqual(got, l.want) {
+			t.Fatalf("ListBlog(%v) = %v, %v, want %v", l.req, got, err, l.want)
+		}
+	}
+
+	if n, err := publishDue(ctx, store, timeNow()); n != 0 || err != nil {
+		t.Fatalf("publishDue(now) = %v, %v, want 0", n, err)
+	}
+	if _, err := c.ReadBlog(as(""), &blogpb.ReadBlogRequest{BlogId: blog.GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("ReadBlog(scheduled blog) = %v, want NotFound", err)
+	}
+	if n, err := publishDue(ctx, store, inAnHour.Add(time.Second)); n != 1 || err != nil {
+		t.Fatalf("publishDue(in an hour) = %v, %v, want 1", n, err)
+	}
+	if got, _, err := listTitles(ctx, c, &blogpb.ListBlogRequest{}); err != nil || !reflect.DeepEqual(got, []string{"later"}) {
+		t.Fatalf("ListBlog() = %v, %v, want [later]", got, err)
+	}
+	read, err := c.ReadBlog(as(""), &blogpb.ReadBlogRequest{BlogId: blog.GetId()})
+	if err != nil || read.GetBlog().GetState() != blogpb.Blog_PUBLISHED {
+		t.Fatalf("ReadBlog() = %v, %v, want a published blog", read, err)
+	}
+}
+
+// listComments lists the contents of every comment of a blog, a page of pageSize at a time
+func listComments(ctx context.Context, c blogpb.BlogServiceClient, blogID string, pageSize int32) ([]string, error) {
+	var contents []string
+	token := ""
+	for {
+		stream, err := c.ListComments(ctx, &blogpb.ListCommentsRequest{BlogId: blogID, PageSize: pageSize, PageToken: token})
+		if err != nil {
+			return nil, err
+		}
+		token = ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			contents = append(contents, res.GetComment().GetContent())
+			token = res.GetNextPageToken()
+		}
+		if token == "" {
+			return contents, nil
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	ctx := as("alice")
+	store := newMemoryStore()
+	c := newTestClient(t, store)
+	blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "commented"})
+	id := blog.GetId()
+
+	// comments of the author of the blog need no moderation
+	var ids []string
+	for _, content := range []string{"first", " second ", "third"} {
+		res, err := c.CreateComment(ctx, &blogpb.CreateCommentRequest{Comment: &blogpb.Comment{BlogId: id, Content: content}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, res.GetComment().GetId())
+	}
+	if got, err := listComments(ctx, c, id, 2); err != nil || !reflect.DeepEqual(got, []string{"first", "second", "third"}) {
+		t.Fatalf("ListComments() = %v, %v, want [first second third]", got, err)
+	}
+
+	if _, err := c.DeleteComment(ctx, &blogpb.DeleteCommentRequest{BlogId: id, CommentId: ids[1]}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := listComments(ctx, c, id, 0); err != nil || !reflect.DeepEqual(got, []string{"first", "third"}) {
+		t.Fatalf("ListComments() after a delete = %v, %v, want [first third]", got, err)
+	}
+
+	invalid := []struct {
+		name    string
+		comment *blogpb.Comment
+		want    codes.Code
+	}{
+		{"empty", &blogpb.Comment{BlogId: id, Content: "  "}, codes.InvalidArgument},
+		{"too long", &blogpb.Comment{BlogId: id, Content: strings.Repeat("x", maxCommentLength+1)}, codes.InvalidArgument},
+		{"malformed blog ID", &blogpb.Comment{BlogId: "nope", Content: "x"}, codes.InvalidArgument},
+		{"unknown blog", &blogpb.Comment{BlogId: "5bdc29e661b75adcac496cf4", Content: "x"}, codes.NotFound},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.CreateComment(ctx, &blogpb.CreateCommentRequest{Comment: tt.comment}); status.Code(err) != tt.want {
+				t.Errorf("CreateComment() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if _, err := c.DeleteComment(ctx, &blogpb.DeleteCommentRequest{BlogId: id, CommentId: ids[1]}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteComment(deleted comment) = %v, want NotFound", err)
+	}
+
+	// comments go away with their blog once it is purged from the trash
+	if _, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := listComments(ctx, c, id, 0); status.Code(err) != codes.NotFound {
+		t.Errorf("ListComments(deleted blog) = %v, want NotFound", err)
+	}
+	if _, err := store.PurgeDeleted(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if left, err := store.ListComments(ctx, commentQuery{BlogID: objectID(t, id), State: blogpb.Comment_APPROVED, Limit: 10}); err != nil || len(left) != 0 {
+		t.Errorf("comments left after purge = %v, %v, want none", left, err)
+	}
+}
+
+// createComment creates a comment as the given caller and fails the test on errors
+func createComment(t *testing.T, c blogpb.BlogServiceClient, authorID string, comment *blogpb.Comment) *blogpb.Comment {
+	t.Helper()
+	res, err := c.CreateComment(as(authorID), &blogpb.CreateCommentRequest{Comment: comment})
+	if err != nil {
+		t.Fatalf("CreateComment() = %v", err)
+	}
+	return res.GetComment()
+}
+
+func TestCommentThreads(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	id := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "commented"}).GetId()
+	otherID := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "other"}).GetId()
+
+	// replies nest up to maxCommentDepth levels under a top-level comment
+	root := createComment(t, c, "alice", &blogpb.Comment{BlogId: id, Content: "root"})
+	parent := root
+	for depth := int32(1); depth <= maxCommentDepth; depth++ {
+		reply := createComment(t, c, "alice", &blogpb.Comment{BlogId: id, ParentCommentId: parent.GetId(), Content: fmt.Sprintf("reply %v", depth)})
+		if reply.GetDepth() != depth || reply.GetParentCommentId() != parent.GetId() {
+			t.Fatalf("CreateComment() = %v, want a reply to %v at depth %v", reply, parent.GetId(), depth)
+		}
+		parent = reply
+	}
+	pending := createComment(t, c, "bob", &blogpb.Comment{BlogId: id, Content: "held"})
+	elsewhere := createComment(t, c, "alice", &blogpb.Comment{BlogId: otherID, Content: "elsewhere"})
+
+	invalid := []struct {
+		name     string
+		parentID string
+		want     codes.Code
+	}{
+		{"too deep", parent.GetId(), codes.InvalidArgument},
+		{"malformed parent", "nope", codes.InvalidArgument},
+		{"unknown parent", "5bdc29e661b75adcac496cf4", codes.NotFound},
+		{"parent on another blog", elsewhere.GetId(), codes.NotFound},
+		{"pending parent", pending.GetId(), codes.FailedPrecondition},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.CreateComment(as("alice"), &blogpb.CreateCommentRequest{Comment: &blogpb.Comment{BlogId: id, ParentCommentId: tt.parentID, Content: "x"}})
+			if status.Code(err) != tt.want {
+				t.Errorf("CreateComment() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// deleting a comment deletes the whole thread under it, and nothing on other blogs
+	sibling := createComment(t, c, "alice", &blogpb.Comment{BlogId: id, Content: "sibling"})
+	if _, err := c.DeleteComment(as("alice"), &blogpb.DeleteCommentRequest{BlogId: id, CommentId: root.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := listComments(as("alice"), c, id, 0); err != nil || !reflect.DeepEqual(got, []string{sibling.GetContent()}) {
+		t.Errorf("ListComments() after deleting a thread = %v, %v, want [sibling]", got, err)
+	}
+	if got, err := listComments(as("alice"), c, otherID, 0); err != nil || !reflect.DeepEqual(got, []string{"elsewhere"}) {
+		t.Errorf("ListComments(other blog) = %v, %v, want [elsewhere]", got, err)
+	}
+	if _, err := c.DeleteComment(as("alice"), &blogpb.DeleteCommentRequest{BlogId: otherID, CommentId: sibling.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteComment() through another blog = %v, want NotFound", err)
+	}
+}
+
+// listPending lists the contents of the pending comments of a blog, or of every blog when blogID is empty
+func listPending(ctx context.Context, c blogpb.BlogServiceClient, blogID string) ([]string, error) {
+	stream, err := c.ListPendingComments(ctx, &blogpb.ListPendingCommentsRequest{BlogId: blogID})
+	if err != nil {
+		return nil, err
+	}
+	var contents []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return contents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, res.GetComment().GetContent())
+	}
+}
+
+func TestCommentModeration(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	id := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "moderated"}).GetId()
+
+	// only the author of the blog and authors already approved somewhere skip moderation
+	states := []struct {
+		authorID string
+		content  string
+		want     blogpb.Comment_State
+	}{
+		{"alice", "by the author", blogpb.Comment_APPROVED},
+		{"bob", "by bob", blogpb.Comment_PENDING},
+		{"", "anonymous", blogpb.Comment_PENDING},
+	}
+	ids := make(map[string]string)
+	for _, tt := range states {
+		created := createComment(t, c, tt.authorID, &blogpb.Comment{BlogId: id, Content: tt.content})
+		if created.GetState() != tt.want || created.GetAuthorId() != tt.authorID {
+			t.Errorf("CreateComment() as %q = %v, want %v by %q", tt.authorID, created, tt.want, tt.authorID)
+		}
+		ids[tt.content] = created.GetId()
+	}
+
+	// pending comments are hidden from every reader, their author and the author of the blog included
+	for _, caller := range []string{"alice", "bob", "", adminRole} {
+		if got, err := listComments(as(caller), c, id, 0); err != nil || !reflect.DeepEqual(got, []string{"by the author"}) {
+			t.Errorf("ListComments() as %q = %v, %v, want [by the author]", caller, got, err)
+		}
+	}
+	// the pending comments of a blog in the trash leave the queue along with it
+	trashed := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "trashed"}).GetId()
+	createComment(t, c, "bob", &blogpb.Comment{BlogId: trashed, Content: "on a trashed blog"})
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: trashed}); err != nil {
+		t.Fatalf("DeleteBlog() = %v", err)
+	}
+	pendingLists := []struct {
+		name   string
+		caller string
+		blogID string
+		want   []string
+		code   codes.Code
+	}{
+		{"author of the blog", "alice", id, []string{"by bob", "anonymous"}, codes.OK},
+		{"admin on every blog", adminRole, "", []string{"by bob", "anonymous"}, codes.OK},
+		{"author of a pending comment", "bob", id, nil, codes.PermissionDenied},
+		{"anonymous", "", id, nil, codes.Unauthenticated},
+		{"unknown blog", adminRole, "5bdc29e661b75adcac496cf4", nil, codes.NotFound},
+		{"blog in the trash", adminRole, trashed, nil, codes.NotFound},
+	}
+	for _, tt := range pendingLists {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := listPending(as(tt.caller), c, tt.blogID)
+			if status.Code(err) != tt.code || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListPendingComments() = %v, %v, want %v, %v", got, err, tt.want, tt.code)
+			}
+		})
+	}
+
+	moderations := []struct {
+		name      string
+		caller    string
+		commentID string
+		state     blogpb.Comment_State
+		want      codes.Code
+	}{
+		{"back to pending", "alice", ids["by bob"], blogpb.Comment_PENDING, codes.InvalidArgument},
+		{"by the comment author", "bob", ids["by bob"], blogpb.Comment_APPROVED, codes.PermissionDenied},
+		{"unknown comment", "alice", "5bdc29e661b75adcac496cf4", blogpb.Comment_APPROVED, codes.NotFound},
+		{"approve", "alice", ids["by bob"], blogpb.Comment_APPROVED, codes.OK},
+		{"reject", adminRole, ids["anonymous"], blogpb.Comment_REJECTED, codes.OK},
+	}
+	for _, tt := range moderations {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.ModerateComment(as(tt.caller), &blogpb.ModerateCommentRequest{BlogId: id, CommentId: tt.commentID, State: tt.state})
+			if status.Code(err) != tt.want {
+				t.Fatalf("ModerateComment() = %v, want %v", err, tt.want)
+			}
+			if err == nil && res.GetComment().GetState() != tt.state {
+				t.Errorf("ModerateComment() = %v, want %v", res.GetComment(), tt.state)
+			}
+		})
+	}
+	if got, err := listComments(as(""), c, id, 0); err != nil || !reflect.DeepEqual(got, []string{"by the author", "by bob"}) {
+		t.Errorf("ListComments() after moderation = %v, %v, want [by the author by bob]", got, err)
+	}
+	if got, err := listPending(as("alice"), c, id); err != nil || got != nil {
+		t.Errorf("ListPendingComments() after moderation = %v, %v, want none", got, err)
+	}
+
+	// bob has an approved comment now, so his next ones go through on any blog
+	otherID := createBlog(t, c, "carol", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "carol's"}).GetId()
+	if got := createComment(t, c, "bob", &blogpb.Comment{BlogId: otherID, Content: "known"}); got.GetState() != blogpb.Comment_APPROVED {
+		t.Errorf("CreateComment() by an approved author = %v, want APPROVED", got)
+	}
+
+	// comments can be deleted by their author and the author of the blog, not by other readers
+	if _, err := c.DeleteComment(as("carol"), &blogpb.DeleteCommentRequest{BlogId: id, CommentId: ids["by bob"]}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DeleteComment() by another reader = %v, want PermissionDenied", err)
+	}
+	if _, err := c.DeleteComment(as("bob"), &blogpb.DeleteCommentRequest{BlogId: id, CommentId: ids["by bob"]}); err != nil {
+		t.Errorf("DeleteComment() by its author = %v", err)
+	}
+}
+
+func TestCommentsOfHiddenBlogs(t *testing.T) {
+	store := newMemoryStore()
+	c := newTestClient(t, store)
+	draftID := createBlog(t, c, "alice", &blogpb.Blog{Title: "draft"}).GetId()
+	createComment(t, c, "alice", &blogpb.Comment{BlogId: draftID, Content: "note to self"})
+
+	// the comments of a draft are as hidden as the draft itself
+	for _, caller := range []string{"bob", ""} {
+		if _, err := c.CreateComment(as(caller), &blogpb.CreateCommentRequest{Comment: &blogpb.Comment{BlogId: draftID, Content: "x"}}); status.Code(err) != codes.NotFound {
+			t.Errorf("CreateComment() on a draft as %q = %v, want NotFound", caller, err)
+		}
+		if _, err := listComments(as(caller), c, draftID, 0); status.Code(err) != codes.NotFound {
+			t.Errorf("ListComments() of a draft as %q = %v, want NotFound", caller, err)
+		}
+	}
+	for _, caller := range []string{"alice", adminRole} {
+		if got, err := listComments(as(caller), c, draftID, 0); err != nil || !reflect.DeepEqual(got, []string{"note to self"}) {
+			t.Errorf("ListComments() of a draft as %q = %v, %v, want [note to self]", caller, got, err)
+		}
+	}
+
+	// comments stay with a blog in the trash, come back with it, and go away when it is purged
+	id := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "trashed"}).GetId()
+	createComment(t, c, "alice", &blogpb.Comment{BlogId: id, Content: "kept"})
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateComment(as("alice"), &blogpb.CreateCommentRequest{Comment: &blogpb.Comment{BlogId: id, Content: "x"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("CreateComment() on a deleted blog = %v, want NotFound", err)
+	}
+	if _, err := c.UndeleteBlog(as("alice"), &blogpb.UndeleteBlogRequest{BlogId: id}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := listComments(as(""), c, id, 0); err != nil || !reflect.DeepEqual(got, []string{"kept"}) {
+		t.Errorf("ListComments() after an undelete = %v, %v, want [kept]", got, err)
+	}
+	if _, err := c.DeleteBlog(as("alice"), &blogpb.DeleteBlogRequest{BlogId: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.PurgeDeleted(context.Background(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if left, err := store.ListComments(context.Background(), commentQuery{BlogID: objectID(t, id), State: blogpb.Comment_APPROVED, Limit: 10}); err != nil || len(left) != 0 {
+		t.Errorf("comments left after purge = %v, %v, want none", left, err)
+	}
+}
+
+// objectID parses a blog ID returned by the server
+func objectID(t *testing.T, id string) primitive.ObjectID {
+	t.Helper()
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return oid
+}
+
+func TestAuthorization(t *testing.T) {
+	c := newTestClient(t, newMemoryStore())
+	blog := createBlog(t, c, "alice", &blogpb.Blog{State: blogpb.Blog_PUBLISHED, Title: "mine"})
+	id := blog.GetId()
+	expired, err := auth.Sign(&auth.Claims{Subject: "alice", ExpiresAt: time.Now().Add(-time.Hour).Unix()}, testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		call func(ctx context.Context) error
+		want codes.Code
+	}{
+		{
+			name: "anonymous create",
+			ctx:  as(""),
+			call: func(ctx context.Context) error {
+				_, err := c.CreateBlog(ctx, &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Title: "x"}})
+				return err
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "malformed token on a public method",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer nope"),
+			call: func(ctx context.Context) error {
+				_, err := c.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: id})
+				return err
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "expired token",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+expired),
+			call: func(ctx context.Context) error {
+				_, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: id})
+				return err
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "update by another author",
+			ctx:  as("bob"),
+			call: func(ctx context.Context) error {
+				_, err := c.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, Title: "x", Version: 1}})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "give a blog away",
+			ctx:  as("alice"),
+			call: func(ctx context.Context) error {
+				_, err := c.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, AuthorId: "bob", Version: 1}, UpdateMask: mask("author_id")})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "unpublish by another author",
+			ctx:  as("bob"),
+			call: func(ctx context.Context) error {
+				_, err := c.UnpublishBlog(ctx, &blogpb.UnpublishBlogRequest{BlogId: id})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "delete by another author",
+			ctx:  as("bob"),
+			call: func(ctx context.Context) error {
+				_, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: id})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "pending comments of every blog",
+			ctx:  as("alice"),
+			call: func(ctx context.Context) error {
+				stream, err := c.ListPendingComments(ctx, &blogpb.ListPendingCommentsRequest{})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "update by an admin",
+			ctx:  as(adminRole),
+			call: func(ctx context.Context) error {
+				_, err := c.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: id, Title: "edited", Version: 1}, UpdateMask: mask("title")})
+				return err
+			},
+			want: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(tt.ctx); status.Code(err) != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// the author of a new blog is the caller, unless an admin creates it
+	spoofed := createBlog(t, c, "alice", &blogpb.Blog{AuthorId: "bob", Title: "x"})
+	if spoofed.GetAuthorId() != "alice" {
+		t.Errorf("CreateBlog() by alice for bob has author %q, want alice", spoofed.GetAuthorId())
+	}
+	onBehalf := createBlog(t, c, adminRole, &blogpb.Blog{AuthorId: "bob", Title: "x"})
+	if onBehalf.GetAuthorId() != "bob" {
+		t.Errorf("CreateBlog() by an admin for bob has author %q, want bob", onBehalf.GetAuthorId())
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+var (
+	// errBlogNotFound is returned by a BlogStore when no blog matches the given ID
+	errBlogNotFound = errors.New("blog not found")
+	// errRevisionNotFound is returned by a BlogStore when a blog has no revision for the given version
+	errRevisionNotFound = errors.New("blog revision not found")
+	// errCommentNotFound is returned by a BlogStore when a blog has no comment with the given ID
+	errCommentNotFound = errors.New("comment not found")
+	// errInvalidResumeToken is returned by BlogStore.Watch when it cannot parse a resume token
+	errInvalidResumeToken = errors.New("invalid resume token")
+	// errResumeTokenExpired is returned by BlogStore.Watch when the events after a resume token are no longer available
+	errResumeTokenExpired = errors.New("resume token expired")
+	// errRequestIDTaken is returned by BlogStore.Create when another blog holds the same request ID
+	errRequestIDTaken = errors.New("request ID already used")
+	// errVersionMismatch is returned by a BlogStore when a conditional write
+	// targets a version of the blog that is no longer current
+	errVersionMismatch = errors.New("blog version does not match")
+)
+
+// BlogStore is the persistence layer used by the blog server.
+// Implementations must be safe for concurrent use.
+type BlogStore interface {
+	// Create inserts a copy of data and returns it with its version at 1 and its ID set, unless data already has one.
+	// It returns errRequestIDTaken when data has a request ID that another blog holds and has not expired.
+	Create(ctx context.Context, data *blogItem) (*blogItem, error)
+	// Read returns the blog with the given ID, even if deleted, or errBlogNotFound
+	Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error)
+	// Update replaces the blog with the same ID if it is still at data.Version,
+	// and returns it with its version incremented.
+	// It returns errBlogNotFound or errVersionMismatch otherwise.
+	Update(ctx context.Context, data *blogItem) (*blogItem, error)
+	// PurgeDeleted permanently removes the blogs deleted before the given time, along with their revisions and comments,
+	// and returns how many blogs were removed
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+
+	// AddRevision records a past version of a blog, doing nothing if that version is already recorded
+	AddRevision(ctx context.Context, rev *revisionItem) error
+	// ListRevisions returns the revisions of a blog from the newest to the oldest,
+	// starting below the given version unless it is 0, and skipping the ones not made while published if publishedOnly is set
+	ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int, publishedOnly bool) ([]*revisionItem, error)
+	// ReadRevision returns the revision of a blog at the given version or errRevisionNotFound
+	ReadRevision(ctx context.Context, blogID primitive.ObjectID, version int64) (*revisionItem, error)
+	// List returns the blogs matching q in the order it asks for
+	List(ctx context.Context, q listQuery) ([]*blogItem, error)
+	// CreateComment inserts a new comment and returns it with its ID set
+	CreateComment(ctx context.Context, comment *commentItem) (*commentItem, error)
+	// ReadComment returns a comment of a blog or errCommentNotFound
+	ReadComment(ctx context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID) (*commentItem, error)
+	// ListComments returns the comments matching q from the oldest to the newest
+	ListComments(ctx context.Context, q commentQuery) ([]*commentItem, error)
+	// SetCommentState changes the moderation state of a comment of a blog and returns the comment,
+	// or returns errCommentNotFound
+	SetCommentState(ctx context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID, state blogpb.Comment_State) (*commentItem, error)
+	// HasApprovedComment tells if the author has at least one approved comment, on any blog
+	HasApprovedComment(ctx context.Context, authorID string) (bool, error)
+	// DeleteComment removes a comment of a blog along with every reply under it, or returns errCommentNotFound
+	DeleteComment(ctx context.Context, blogID primitive.ObjectID, commentID primitive.ObjectID) error
+
+	// ListScheduled returns up to limit live blogs scheduled to be published at or before the given time,
+	// the earliest first
+	ListScheduled(ctx context.Context, before time.Time, limit int) ([]*blogItem, error)
+	// CountTags returns every tag of the live published blogs with the number of blogs having it,
+	// the most used first and ties in alphabetical order
+	CountTags(ctx context.Context) ([]tagCount, error)
+
+	// Search returns the live published blogs matching a full-text query, most relevant first,
+	// skipping the first offset results
+	Search(ctx context.Context, query string, offset, limit int) ([]*searchHit, error)
+
+	// Watch calls fn for every change to a blog, starting after resumeToken or from now on when it is empty.
+	// It blocks until ctx is done or fn returns an error.
+	Watch(ctx context.Context, resumeToken string, fn func(blogEvent) error) error
+
+	// ReadByRequestID returns the blog holding a CreateBlog request ID that has not expired at now,
+	// even if deleted, or errBlogNotFound
+	ReadByRequestID(ctx context.Context, key requestKey, now time.Time) (*blogItem, error)
+
+	// Close releases any resources held by the store
+	Close(ctx context.Context) error
+}
+
+// listQuery selects a window of blogs for BlogStore.List
+type listQuery struct {
+	Filter blogFilter
+	Order  blogOrder
+	// After resumes the listing after this position when it is not nil
+	After *listCursor
+	// Limit is the maximum number of blogs to return
+	Limit int
+}
+
+// commentQuery selects a window of comments for BlogStore.ListComments
+type commentQuery struct {
+	// BlogID selects the comments of this blog, or of every blog not in the trash when it is zero
+	BlogID primitive.ObjectID
+	State  blogpb.Comment_State
+	// After resumes the listing after the comment with this ID when it is not zero
+	After primitive.ObjectID
+	// Limit is the maximum number of comments to return
+	Limit int
+}
+
+// searchHit is a blog found by BlogStore.Search
+type searchHit struct {
+	Blog  *blogItem
+	Score float64
+}
+
+// listCursor is the position of a blog in a listing
+type listCursor struct {
+	ID primitive.ObjectID
+	// Key is the sort key of the blog, unused when ordering by ID
+	Key string
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+const (
+	// maxTags is the maximum number of tags on a blog
+	maxTags = 20
+	// maxTagLength is the maximum length of a tag in bytes
+	maxTagLength = 64
+)
+
+// tagCount is the number of blogs with a tag, as returned by BlogStore.CountTags
+type tagCount struct {
+	Tag   string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+// normalizeTags lower cases and trims tags, dropping duplicates while keeping their order
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) > maxTags {
+		return nil, fmt.Errorf("a blog cannot have more than %v tags", maxTags)
+	}
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, fmt.Errorf("tags cannot be empty")
+		}
+		if len(tag) > maxTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %v characters", tag, maxTagLength)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+func (s *server) ListTags(ctx context.Context, _ *blogpb.ListTagsRequest) (*blogpb.ListTagsResponse, error) {
+	fmt.Println("List tags request")
+
+	counts, err := s.store.CountTags(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unknown internal error: %v", err,
+		)
+	}
+
+	res := &blogpb.ListTagsResponse{}
+	for _, c := range counts {
+		res.Tags = append(res.Tags, &blogpb.TagCount{Tag: c.Tag, Count: c.Count})
+	}
+	return res, nil
+}
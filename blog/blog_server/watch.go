@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/simplesteph/grpc-go-course/blog/blogpb"
+)
+
+// blogEvent is a change to a blog as reported by BlogStore.Watch
+type blogEvent struct {
+	Type        blogpb.BlogEvent_Type
+	Blog        *blogItem
+	ResumeToken string
+}
+
+// eventTypeOf classifies a write from the blog it produced.
+// Both stores rely on it so the same write is reported the same way whatever the backend:
+// blogs are only ever written while live, or by DeleteBlog and UndeleteBlog.
+func eventTypeOf(data *blogItem, inserted bool) blogpb.BlogEvent_Type {
+	switch {
+	case inserted:
+		return blogpb.BlogEvent_CREATED
+	case data.DeleteTime != nil:
+		return blogpb.BlogEvent_DELETED
+	default:
+		return blogpb.BlogEvent_UPDATED
+	}
+}
+
+// watchFilter decides which events a watcher gets, from the blogs it may see
+type watchFilter struct {
+	caller *caller
+	// visible tells, for the blogs a watcher received events about, if it may still see them
+	visible map[primitive.ObjectID]bool
+}
+
+func newWatchFilter(c *caller) *watchFilter {
+	return &watchFilter{caller: c, visible: map[primitive.ObjectID]bool{}}
+}
+
+// event returns what the watcher gets of ev, nil when it must not hear about it at all.
+// Blogs leaving what the watcher may see are reported as REMOVED, without their content.
+func (f *watchFilter) event(ev blogEvent) *blogpb.BlogEvent {
+	if f.caller.canView(ev.Blog) {
+		f.visible[ev.Blog.ID] = true
+		return &blogpb.BlogEvent{
+			Type:        ev.Type,
+			Blog:        dataToBlogPb(ev.Blog),
+			ResumeToken: ev.ResumeToken,
+		}
+	}
+
+	wasVisible, known := f.visible[ev.Blog.ID]
+	if !known {
+		wasVisible = mayHaveBeenPublished(ev)
+	}
+	f.visible[ev.Blog.ID] = false
+	if !wasVisible {
+		return nil
+	}
+	return &blogpb.BlogEvent{
+		Type:        blogpb.BlogEvent_REMOVED,
+		Blog:        &blogpb.Blog{Id: ev.Blog.ID.Hex()},
+		ResumeToken: ev.ResumeToken,
+	}
+}
+
+// mayHaveBeenPublished tells if the blog of an event the watcher may not see was live and published
+// before it, which is all a watcher can tell about a blog that changed before the watch started.
+// Deleting a blog keeps its state, and the only updates hiding a live blog unpublish it.
+func mayHaveBeenPublished(ev blogEvent) bool {
+	switch ev.Type {
+	case blogpb.BlogEvent_DELETED:
+		return ev.Blog.State == blogpb.Blog_PUBLISHED
+	case blogpb.BlogEvent_UPDATED:
+		return ev.Blog.DeleteTime == nil && (ev.Blog.State == blogpb.Blog_DRAFT || ev.Blog.State == blogpb.Blog_ARCHIVED)
+	}
+	return false
+}
+
+func (s *server) WatchBlogs(req *blogpb.WatchBlogsRequest, stream blogpb.BlogService_WatchBlogsServer) error {
+	fmt.Println("Watch blogs request")
+	ctx := stream.Context()
+	filter := newWatchFilter(callerFromContext(ctx))
+
+	err := s.store.Watch(ctx, req.GetResumeToken(), func(ev blogEvent) error {
+		if req.GetAuthorId() != "" && ev.Blog.AuthorID != req.GetAuthorId() {
+			return nil
+		}
+		event := filter.event(ev)
+		if event == nil {
+			return nil
+		}
+		return stream.Send(&blogpb.WatchBlogsResponse{Event: event})
+	})
+	switch {
+	case ctx.Err() != nil:
+		// the client went away
+		return status.FromContextError(ctx.Err()).Err()
+	case err == errInvalidResumeToken:
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Cannot parse resume token: %v", err,
+		)
+	case err == errResumeTokenExpired:
+		return status.Errorf(
+			codes.OutOfRange,
+			"Events after the resume token are no longer available, list the blogs and watch again: %v", err,
+		)
+	case err != nil:
+		return status.Errorf(
+			codes.Internal,
+			"Unknown internal error: %v", err,
+		)
+	}
+	return nil
+}
@@ -6,6 +6,8 @@ package blogpb
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+import field_mask "google.golang.org/genproto/protobuf/field_mask"
 
 import (
 	context "golang.org/x/net/context"
@@ -23,21 +25,167 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type TagMatch int32
+
+const (
+	// the blog must have every tag
+	TagMatch_TAG_MATCH_ALL TagMatch = 0
+	// the blog must have at least one of the tags
+	TagMatch_TAG_MATCH_ANY TagMatch = 1
+)
+
+var TagMatch_name = map[int32]string{
+	0: "TAG_MATCH_ALL",
+	1: "TAG_MATCH_ANY",
+}
+var TagMatch_value = map[string]int32{
+	"TAG_MATCH_ALL": 0,
+	"TAG_MATCH_ANY": 1,
+}
+
+func (x TagMatch) String() string {
+	return proto.EnumName(TagMatch_name, int32(x))
+}
+func (TagMatch) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{0}
+}
+
+// only PUBLISHED blogs are visible to readers
+type Blog_State int32
+
+const (
+	// blogs written before states existed are PUBLISHED
+	Blog_STATE_UNSPECIFIED Blog_State = 0
+	Blog_DRAFT             Blog_State = 1
+	// published automatically once publish_time is reached
+	Blog_SCHEDULED Blog_State = 2
+	Blog_PUBLISHED Blog_State = 3
+	// taken down after being published
+	Blog_ARCHIVED Blog_State = 4
+)
+
+var Blog_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "DRAFT",
+	2: "SCHEDULED",
+	3: "PUBLISHED",
+	4: "ARCHIVED",
+}
+var Blog_State_value = map[string]int32{
+	"STATE_UNSPECIFIED": 0,
+	"DRAFT":             1,
+	"SCHEDULED":         2,
+	"PUBLISHED":         3,
+	"ARCHIVED":          4,
+}
+
+func (x Blog_State) String() string {
+	return proto.EnumName(Blog_State_name, int32(x))
+}
+func (Blog_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{0, 0}
+}
+
+type BlogEvent_Type int32
+
+const (
+	BlogEvent_TYPE_UNSPECIFIED BlogEvent_Type = 0
+	BlogEvent_CREATED          BlogEvent_Type = 1
+	// also sent when a blog is restored from the trash
+	BlogEvent_UPDATED BlogEvent_Type = 2
+	// the blog was moved to the trash
+	BlogEvent_DELETED BlogEvent_Type = 3
+	// the blog is no longer visible to the watcher, because it was unpublished or moved to the trash,
+	// only its id is set. It may also come for a blog the watcher was not sent yet,
+	// when the watch started after the blog was published.
+	BlogEvent_REMOVED BlogEvent_Type = 4
+)
+
+var BlogEvent_Type_name = map[int32]string{
+	0: "TYPE_UNSPECIFIED",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+	4: "REMOVED",
+}
+var BlogEvent_Type_value = map[string]int32{
+	"TYPE_UNSPECIFIED": 0,
+	"CREATED":          1,
+	"UPDATED":          2,
+	"DELETED":          3,
+	"REMOVED":          4,
+}
+
+func (x BlogEvent_Type) String() string {
+	return proto.EnumName(BlogEvent_Type_name, int32(x))
+}
+func (BlogEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{2, 0}
+}
+
+// only APPROVED comments are visible to readers
+type Comment_State int32
+
+const (
+	// comments written before moderation existed are APPROVED
+	Comment_STATE_UNSPECIFIED Comment_State = 0
+	// held for a moderator, new comments from unknown authors start here
+	Comment_PENDING  Comment_State = 1
+	Comment_APPROVED Comment_State = 2
+	Comment_REJECTED Comment_State = 3
+)
+
+var Comment_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "PENDING",
+	2: "APPROVED",
+	3: "REJECTED",
+}
+var Comment_State_value = map[string]int32{
+	"STATE_UNSPECIFIED": 0,
+	"PENDING":           1,
+	"APPROVED":          2,
+	"REJECTED":          3,
+}
+
+func (x Comment_State) String() string {
+	return proto.EnumName(Comment_State_name, int32(x))
+}
+func (Comment_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{3, 0}
+}
+
 type Blog struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AuthorId             string   `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// set by the server to the caller creating the blog, only admins can pick another author
+	AuthorId string `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title    string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content  string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// incremented by the server on every update, starting at 1
+	Version int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// set by the server when the blog is created, ignored in requests
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// set by the server every time the blog is updated, ignored in requests
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// set by the server when the blog is moved to the trash, unset otherwise
+	DeleteTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	// stored in lower case without duplicates
+	Tags []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// changed with PublishBlog and UnpublishBlog, ignored by UpdateBlog
+	// CreateBlog makes a DRAFT unless asked for PUBLISHED or SCHEDULED
+	State Blog_State `protobuf:"varint,10,opt,name=state,proto3,enum=blog.Blog_State" json:"state,omitempty"`
+	// when the blog was or will be published, unset for drafts
+	PublishTime          *timestamp.Timestamp `protobuf:"bytes,11,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *Blog) Reset()         { *m = Blog{} }
 func (m *Blog) String() string { return proto.CompactTextString(m) }
 func (*Blog) ProtoMessage()    {}
 func (*Blog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{0}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{0}
 }
 func (m *Blog) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Blog.Unmarshal(m, b)
@@ -85,8 +233,309 @@ func (m *Blog) GetContent() string {
 	return ""
 }
 
+func (m *Blog) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Blog) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Blog) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *Blog) GetDeleteTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeleteTime
+	}
+	return nil
+}
+
+func (m *Blog) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Blog) GetState() Blog_State {
+	if m != nil {
+		return m.State
+	}
+	return Blog_STATE_UNSPECIFIED
+}
+
+func (m *Blog) GetPublishTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishTime
+	}
+	return nil
+}
+
+// BlogRevision is the state of a blog before one of its updates
+type BlogRevision struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// version of the blog this revision captures
+	Version  int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	AuthorId string `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title    string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Content  string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	// when the blog was saved with this content
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	Tags                 []string             `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BlogRevision) Reset()         { *m = BlogRevision{} }
+func (m *BlogRevision) String() string { return proto.CompactTextString(m) }
+func (*BlogRevision) ProtoMessage()    {}
+func (*BlogRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{1}
+}
+func (m *BlogRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogRevision.Unmarshal(m, b)
+}
+func (m *BlogRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogRevision.Marshal(b, m, deterministic)
+}
+func (dst *BlogRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogRevision.Merge(dst, src)
+}
+func (m *BlogRevision) XXX_Size() int {
+	return xxx_messageInfo_BlogRevision.Size(m)
+}
+func (m *BlogRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogRevision proto.InternalMessageInfo
+
+func (m *BlogRevision) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BlogRevision) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *BlogRevision) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// BlogEvent is a change to a blog, as streamed by WatchBlogs
+type BlogEvent struct {
+	Type BlogEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=blog.BlogEvent_Type" json:"type,omitempty"`
+	// the blog after the change
+	// watchers only get the events of live published blogs, unless they are the author or an admin
+	Blog *Blog `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	// pass it to WatchBlogs to resume watching right after this event
+	ResumeToken          string   `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlogEvent) Reset()         { *m = BlogEvent{} }
+func (m *BlogEvent) String() string { return proto.CompactTextString(m) }
+func (*BlogEvent) ProtoMessage()    {}
+func (*BlogEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{2}
+}
+func (m *BlogEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogEvent.Unmarshal(m, b)
+}
+func (m *BlogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogEvent.Marshal(b, m, deterministic)
+}
+func (dst *BlogEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogEvent.Merge(dst, src)
+}
+func (m *BlogEvent) XXX_Size() int {
+	return xxx_messageInfo_BlogEvent.Size(m)
+}
+func (m *BlogEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogEvent proto.InternalMessageInfo
+
+func (m *BlogEvent) GetType() BlogEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return BlogEvent_TYPE_UNSPECIFIED
+}
+
+func (m *BlogEvent) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *BlogEvent) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+// Comment is a reader comment on a blog, or a reply to another comment
+type Comment struct {
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlogId string `protobuf:"bytes,2,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// set by the server to the caller, empty for anonymous comments
+	AuthorId string `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Content  string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// set by the server when the comment is created, ignored in requests
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// the comment this one replies to, empty for a top-level comment
+	ParentCommentId string `protobuf:"bytes,6,opt,name=parent_comment_id,json=parentCommentId,proto3" json:"parent_comment_id,omitempty"`
+	// set by the server, 0 for a top-level comment and one more than its parent for a reply
+	Depth int32 `protobuf:"varint,7,opt,name=depth,proto3" json:"depth,omitempty"`
+	// set by the server, changed with ModerateComment
+	State                Comment_State `protobuf:"varint,8,opt,name=state,proto3,enum=blog.Comment_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Comment) Reset()         { *m = Comment{} }
+func (m *Comment) String() string { return proto.CompactTextString(m) }
+func (*Comment) ProtoMessage()    {}
+func (*Comment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{3}
+}
+func (m *Comment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Comment.Unmarshal(m, b)
+}
+func (m *Comment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Comment.Marshal(b, m, deterministic)
+}
+func (dst *Comment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Comment.Merge(dst, src)
+}
+func (m *Comment) XXX_Size() int {
+	return xxx_messageInfo_Comment.Size(m)
+}
+func (m *Comment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Comment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Comment proto.InternalMessageInfo
+
+func (m *Comment) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Comment) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *Comment) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *Comment) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *Comment) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Comment) GetParentCommentId() string {
+	if m != nil {
+		return m.ParentCommentId
+	}
+	return ""
+}
+
+func (m *Comment) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *Comment) GetState() Comment_State {
+	if m != nil {
+		return m.State
+	}
+	return Comment_STATE_UNSPECIFIED
+}
+
 type CreateBlogRequest struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// optional client-generated ID, such as a UUID, making retries safe:
+	// repeating a request_id returns the blog created by the first request instead of creating another one.
+	// Request IDs are scoped to the caller, and a retry fails once that blog was deleted
+	// (FAILED_PRECONDITION) or moved to an author the caller cannot manage (ALREADY_EXISTS)
+	RequestId            string   `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -96,7 +545,7 @@ func (m *CreateBlogRequest) Reset()         { *m = CreateBlogRequest{} }
 func (m *CreateBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateBlogRequest) ProtoMessage()    {}
 func (*CreateBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{1}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{4}
 }
 func (m *CreateBlogRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateBlogRequest.Unmarshal(m, b)
@@ -123,6 +572,13 @@ func (m *CreateBlogRequest) GetBlog() *Blog {
 	return nil
 }
 
+func (m *CreateBlogRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
 type CreateBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -134,7 +590,7 @@ func (m *CreateBlogResponse) Reset()         { *m = CreateBlogResponse{} }
 func (m *CreateBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateBlogResponse) ProtoMessage()    {}
 func (*CreateBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{2}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{5}
 }
 func (m *CreateBlogResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateBlogResponse.Unmarshal(m, b)
@@ -172,7 +628,7 @@ func (m *ReadBlogRequest) Reset()         { *m = ReadBlogRequest{} }
 func (m *ReadBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadBlogRequest) ProtoMessage()    {}
 func (*ReadBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{3}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{6}
 }
 func (m *ReadBlogRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadBlogRequest.Unmarshal(m, b)
@@ -210,7 +666,7 @@ func (m *ReadBlogResponse) Reset()         { *m = ReadBlogResponse{} }
 func (m *ReadBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadBlogResponse) ProtoMessage()    {}
 func (*ReadBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{4}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{7}
 }
 func (m *ReadBlogResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadBlogResponse.Unmarshal(m, b)
@@ -238,17 +694,22 @@ func (m *ReadBlogResponse) GetBlog() *Blog {
 }
 
 type UpdateBlogRequest struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// blog.version must be the version the caller read, the update is ABORTED if the blog changed since
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// fields of blog to update, e.g. "title", or title and content when empty
+	// supported paths: author_id (admins only), title, content, tags and * for all of them but author_id,
+	// which must always be listed to change the author
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *UpdateBlogRequest) Reset()         { *m = UpdateBlogRequest{} }
 func (m *UpdateBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateBlogRequest) ProtoMessage()    {}
 func (*UpdateBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{5}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{8}
 }
 func (m *UpdateBlogRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateBlogRequest.Unmarshal(m, b)
@@ -275,6 +736,13 @@ func (m *UpdateBlogRequest) GetBlog() *Blog {
 	return nil
 }
 
+func (m *UpdateBlogRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
 type UpdateBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -286,7 +754,7 @@ func (m *UpdateBlogResponse) Reset()         { *m = UpdateBlogResponse{} }
 func (m *UpdateBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateBlogResponse) ProtoMessage()    {}
 func (*UpdateBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{6}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{9}
 }
 func (m *UpdateBlogResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateBlogResponse.Unmarshal(m, b)
@@ -314,7 +782,9 @@ func (m *UpdateBlogResponse) GetBlog() *Blog {
 }
 
 type DeleteBlogRequest struct {
-	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// when set, the delete is ABORTED unless the blog is still at this version
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -324,7 +794,7 @@ func (m *DeleteBlogRequest) Reset()         { *m = DeleteBlogRequest{} }
 func (m *DeleteBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteBlogRequest) ProtoMessage()    {}
 func (*DeleteBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{7}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{10}
 }
 func (m *DeleteBlogRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteBlogRequest.Unmarshal(m, b)
@@ -351,6 +821,13 @@ func (m *DeleteBlogRequest) GetBlogId() string {
 	return ""
 }
 
+func (m *DeleteBlogRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type DeleteBlogResponse struct {
 	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -362,7 +839,7 @@ func (m *DeleteBlogResponse) Reset()         { *m = DeleteBlogResponse{} }
 func (m *DeleteBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteBlogResponse) ProtoMessage()    {}
 func (*DeleteBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{8}
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{11}
 }
 func (m *DeleteBlogResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteBlogResponse.Unmarshal(m, b)
@@ -389,76 +866,1506 @@ func (m *DeleteBlogResponse) GetBlogId() string {
 	return ""
 }
 
-type ListBlogRequest struct {
+type UndeleteBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// when set, the undelete is ABORTED unless the blog is still at this version
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListBlogRequest) Reset()         { *m = ListBlogRequest{} }
-func (m *ListBlogRequest) String() string { return proto.CompactTextString(m) }
-func (*ListBlogRequest) ProtoMessage()    {}
-func (*ListBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{9}
+func (m *UndeleteBlogRequest) Reset()         { *m = UndeleteBlogRequest{} }
+func (m *UndeleteBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeleteBlogRequest) ProtoMessage()    {}
+func (*UndeleteBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{12}
 }
-func (m *ListBlogRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListBlogRequest.Unmarshal(m, b)
+func (m *UndeleteBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteBlogRequest.Unmarshal(m, b)
 }
-func (m *ListBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListBlogRequest.Marshal(b, m, deterministic)
+func (m *UndeleteBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteBlogRequest.Marshal(b, m, deterministic)
 }
-func (dst *ListBlogRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListBlogRequest.Merge(dst, src)
+func (dst *UndeleteBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteBlogRequest.Merge(dst, src)
 }
-func (m *ListBlogRequest) XXX_Size() int {
-	return xxx_messageInfo_ListBlogRequest.Size(m)
+func (m *UndeleteBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_UndeleteBlogRequest.Size(m)
 }
-func (m *ListBlogRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListBlogRequest.DiscardUnknown(m)
+func (m *UndeleteBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteBlogRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListBlogRequest proto.InternalMessageInfo
+var xxx_messageInfo_UndeleteBlogRequest proto.InternalMessageInfo
 
-type ListBlogResponse struct {
+func (m *UndeleteBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *UndeleteBlogRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type UndeleteBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListBlogResponse) Reset()         { *m = ListBlogResponse{} }
-func (m *ListBlogResponse) String() string { return proto.CompactTextString(m) }
-func (*ListBlogResponse) ProtoMessage()    {}
-func (*ListBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blog_f36092d2f7e14931, []int{10}
+func (m *UndeleteBlogResponse) Reset()         { *m = UndeleteBlogResponse{} }
+func (m *UndeleteBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*UndeleteBlogResponse) ProtoMessage()    {}
+func (*UndeleteBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{13}
 }
-func (m *ListBlogResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListBlogResponse.Unmarshal(m, b)
+func (m *UndeleteBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteBlogResponse.Unmarshal(m, b)
 }
-func (m *ListBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListBlogResponse.Marshal(b, m, deterministic)
+func (m *UndeleteBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteBlogResponse.Marshal(b, m, deterministic)
 }
-func (dst *ListBlogResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListBlogResponse.Merge(dst, src)
+func (dst *UndeleteBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteBlogResponse.Merge(dst, src)
 }
-func (m *ListBlogResponse) XXX_Size() int {
-	return xxx_messageInfo_ListBlogResponse.Size(m)
+func (m *UndeleteBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_UndeleteBlogResponse.Size(m)
 }
-func (m *ListBlogResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListBlogResponse.DiscardUnknown(m)
+func (m *UndeleteBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteBlogResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListBlogResponse proto.InternalMessageInfo
+var xxx_messageInfo_UndeleteBlogResponse proto.InternalMessageInfo
 
-func (m *ListBlogResponse) GetBlog() *Blog {
+func (m *UndeleteBlogResponse) GetBlog() *Blog {
 	if m != nil {
 		return m.Blog
 	}
 	return nil
 }
 
+type ListBlogRevisionsRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// maximum number of revisions to return, the server picks a default when 0 and caps large values
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous ListBlogRevisions call, empty to start from the latest revision
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogRevisionsRequest) Reset()         { *m = ListBlogRevisionsRequest{} }
+func (m *ListBlogRevisionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRevisionsRequest) ProtoMessage()    {}
+func (*ListBlogRevisionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{14}
+}
+func (m *ListBlogRevisionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Unmarshal(m, b)
+}
+func (m *ListBlogRevisionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListBlogRevisionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRevisionsRequest.Merge(dst, src)
+}
+func (m *ListBlogRevisionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Size(m)
+}
+func (m *ListBlogRevisionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRevisionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRevisionsRequest proto.InternalMessageInfo
+
+func (m *ListBlogRevisionsRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ListBlogRevisionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListBlogRevisionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListBlogRevisionsResponse struct {
+	Revision *BlogRevision `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// only set on the last message of a page, empty when there are no more revisions
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogRevisionsResponse) Reset()         { *m = ListBlogRevisionsResponse{} }
+func (m *ListBlogRevisionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRevisionsResponse) ProtoMessage()    {}
+func (*ListBlogRevisionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{15}
+}
+func (m *ListBlogRevisionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Unmarshal(m, b)
+}
+func (m *ListBlogRevisionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListBlogRevisionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRevisionsResponse.Merge(dst, src)
+}
+func (m *ListBlogRevisionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Size(m)
+}
+func (m *ListBlogRevisionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRevisionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRevisionsResponse proto.InternalMessageInfo
+
+func (m *ListBlogRevisionsResponse) GetRevision() *BlogRevision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
+func (m *ListBlogRevisionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetBlogRevisionRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlogRevisionRequest) Reset()         { *m = GetBlogRevisionRequest{} }
+func (m *GetBlogRevisionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlogRevisionRequest) ProtoMessage()    {}
+func (*GetBlogRevisionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{16}
+}
+func (m *GetBlogRevisionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogRevisionRequest.Unmarshal(m, b)
+}
+func (m *GetBlogRevisionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogRevisionRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBlogRevisionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogRevisionRequest.Merge(dst, src)
+}
+func (m *GetBlogRevisionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlogRevisionRequest.Size(m)
+}
+func (m *GetBlogRevisionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogRevisionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlogRevisionRequest proto.InternalMessageInfo
+
+func (m *GetBlogRevisionRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *GetBlogRevisionRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type GetBlogRevisionResponse struct {
+	Revision             *BlogRevision `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetBlogRevisionResponse) Reset()         { *m = GetBlogRevisionResponse{} }
+func (m *GetBlogRevisionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlogRevisionResponse) ProtoMessage()    {}
+func (*GetBlogRevisionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{17}
+}
+func (m *GetBlogRevisionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogRevisionResponse.Unmarshal(m, b)
+}
+func (m *GetBlogRevisionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogRevisionResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetBlogRevisionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogRevisionResponse.Merge(dst, src)
+}
+func (m *GetBlogRevisionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlogRevisionResponse.Size(m)
+}
+func (m *GetBlogRevisionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogRevisionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlogRevisionResponse proto.InternalMessageInfo
+
+func (m *GetBlogRevisionResponse) GetRevision() *BlogRevision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
+type RestoreBlogRevisionRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// version of the revision to restore
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// when set, the restore is ABORTED unless the blog is still at this version
+	BlogVersion          int64    `protobuf:"varint,3,opt,name=blog_version,json=blogVersion,proto3" json:"blog_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBlogRevisionRequest) Reset()         { *m = RestoreBlogRevisionRequest{} }
+func (m *RestoreBlogRevisionRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogRevisionRequest) ProtoMessage()    {}
+func (*RestoreBlogRevisionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{18}
+}
+func (m *RestoreBlogRevisionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogRevisionRequest.Unmarshal(m, b)
+}
+func (m *RestoreBlogRevisionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogRevisionRequest.Marshal(b, m, deterministic)
+}
+func (dst *RestoreBlogRevisionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogRevisionRequest.Merge(dst, src)
+}
+func (m *RestoreBlogRevisionRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogRevisionRequest.Size(m)
+}
+func (m *RestoreBlogRevisionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogRevisionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBlogRevisionRequest proto.InternalMessageInfo
+
+func (m *RestoreBlogRevisionRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *RestoreBlogRevisionRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *RestoreBlogRevisionRequest) GetBlogVersion() int64 {
+	if m != nil {
+		return m.BlogVersion
+	}
+	return 0
+}
+
+type RestoreBlogRevisionResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBlogRevisionResponse) Reset()         { *m = RestoreBlogRevisionResponse{} }
+func (m *RestoreBlogRevisionResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogRevisionResponse) ProtoMessage()    {}
+func (*RestoreBlogRevisionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{19}
+}
+func (m *RestoreBlogRevisionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogRevisionResponse.Unmarshal(m, b)
+}
+func (m *RestoreBlogRevisionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogRevisionResponse.Marshal(b, m, deterministic)
+}
+func (dst *RestoreBlogRevisionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogRevisionResponse.Merge(dst, src)
+}
+func (m *RestoreBlogRevisionResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogRevisionResponse.Size(m)
+}
+func (m *RestoreBlogRevisionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogRevisionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBlogRevisionResponse proto.InternalMessageInfo
+
+func (m *RestoreBlogRevisionResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type PublishBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// publishes the blog right away when unset or in the past, schedules it otherwise
+	PublishTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
+	// when set, the publish is ABORTED unless the blog is still at this version
+	Version              int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishBlogRequest) Reset()         { *m = PublishBlogRequest{} }
+func (m *PublishBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishBlogRequest) ProtoMessage()    {}
+func (*PublishBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{20}
+}
+func (m *PublishBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBlogRequest.Unmarshal(m, b)
+}
+func (m *PublishBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBlogRequest.Marshal(b, m, deterministic)
+}
+func (dst *PublishBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBlogRequest.Merge(dst, src)
+}
+func (m *PublishBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishBlogRequest.Size(m)
+}
+func (m *PublishBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBlogRequest proto.InternalMessageInfo
+
+func (m *PublishBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *PublishBlogRequest) GetPublishTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishTime
+	}
+	return nil
+}
+
+func (m *PublishBlogRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type PublishBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishBlogResponse) Reset()         { *m = PublishBlogResponse{} }
+func (m *PublishBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishBlogResponse) ProtoMessage()    {}
+func (*PublishBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{21}
+}
+func (m *PublishBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBlogResponse.Unmarshal(m, b)
+}
+func (m *PublishBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBlogResponse.Marshal(b, m, deterministic)
+}
+func (dst *PublishBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBlogResponse.Merge(dst, src)
+}
+func (m *PublishBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishBlogResponse.Size(m)
+}
+func (m *PublishBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBlogResponse proto.InternalMessageInfo
+
+func (m *PublishBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type UnpublishBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// moves the blog to ARCHIVED instead of back to DRAFT
+	Archive bool `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	// when set, the unpublish is ABORTED unless the blog is still at this version
+	Version              int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpublishBlogRequest) Reset()         { *m = UnpublishBlogRequest{} }
+func (m *UnpublishBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*UnpublishBlogRequest) ProtoMessage()    {}
+func (*UnpublishBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{22}
+}
+func (m *UnpublishBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpublishBlogRequest.Unmarshal(m, b)
+}
+func (m *UnpublishBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpublishBlogRequest.Marshal(b, m, deterministic)
+}
+func (dst *UnpublishBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpublishBlogRequest.Merge(dst, src)
+}
+func (m *UnpublishBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_UnpublishBlogRequest.Size(m)
+}
+func (m *UnpublishBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpublishBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpublishBlogRequest proto.InternalMessageInfo
+
+func (m *UnpublishBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *UnpublishBlogRequest) GetArchive() bool {
+	if m != nil {
+		return m.Archive
+	}
+	return false
+}
+
+func (m *UnpublishBlogRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type UnpublishBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpublishBlogResponse) Reset()         { *m = UnpublishBlogResponse{} }
+func (m *UnpublishBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*UnpublishBlogResponse) ProtoMessage()    {}
+func (*UnpublishBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{23}
+}
+func (m *UnpublishBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpublishBlogResponse.Unmarshal(m, b)
+}
+func (m *UnpublishBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpublishBlogResponse.Marshal(b, m, deterministic)
+}
+func (dst *UnpublishBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpublishBlogResponse.Merge(dst, src)
+}
+func (m *UnpublishBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_UnpublishBlogResponse.Size(m)
+}
+func (m *UnpublishBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpublishBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpublishBlogResponse proto.InternalMessageInfo
+
+func (m *UnpublishBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type CreateCommentRequest struct {
+	// comment.blog_id is the blog to comment on, and comment.parent_comment_id the comment to reply to if any
+	Comment              *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateCommentRequest) Reset()         { *m = CreateCommentRequest{} }
+func (m *CreateCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateCommentRequest) ProtoMessage()    {}
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{24}
+}
+func (m *CreateCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCommentRequest.Unmarshal(m, b)
+}
+func (m *CreateCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCommentRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCommentRequest.Merge(dst, src)
+}
+func (m *CreateCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateCommentRequest.Size(m)
+}
+func (m *CreateCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCommentRequest proto.InternalMessageInfo
+
+func (m *CreateCommentRequest) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type CreateCommentResponse struct {
+	Comment              *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateCommentResponse) Reset()         { *m = CreateCommentResponse{} }
+func (m *CreateCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateCommentResponse) ProtoMessage()    {}
+func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{25}
+}
+func (m *CreateCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCommentResponse.Unmarshal(m, b)
+}
+func (m *CreateCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCommentResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCommentResponse.Merge(dst, src)
+}
+func (m *CreateCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateCommentResponse.Size(m)
+}
+func (m *CreateCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCommentResponse proto.InternalMessageInfo
+
+func (m *CreateCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// maximum number of comments to return, the server picks a default when 0 and caps large values
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous ListComments call, empty to start from the oldest comment
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsRequest) Reset()         { *m = ListCommentsRequest{} }
+func (m *ListCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsRequest) ProtoMessage()    {}
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{26}
+}
+func (m *ListCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsRequest.Unmarshal(m, b)
+}
+func (m *ListCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsRequest.Merge(dst, src)
+}
+func (m *ListCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsRequest.Size(m)
+}
+func (m *ListCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsRequest proto.InternalMessageInfo
+
+func (m *ListCommentsRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ListCommentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListCommentsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	Comment *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	// only set on the last message of a page, empty when there are no more comments
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsResponse) Reset()         { *m = ListCommentsResponse{} }
+func (m *ListCommentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsResponse) ProtoMessage()    {}
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{27}
+}
+func (m *ListCommentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsResponse.Unmarshal(m, b)
+}
+func (m *ListCommentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListCommentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsResponse.Merge(dst, src)
+}
+func (m *ListCommentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsResponse.Size(m)
+}
+func (m *ListCommentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsResponse proto.InternalMessageInfo
+
+func (m *ListCommentsResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+func (m *ListCommentsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ModerateCommentRequest struct {
+	BlogId    string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	CommentId string `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	// APPROVED or REJECTED
+	State                Comment_State `protobuf:"varint,3,opt,name=state,proto3,enum=blog.Comment_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ModerateCommentRequest) Reset()         { *m = ModerateCommentRequest{} }
+func (m *ModerateCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*ModerateCommentRequest) ProtoMessage()    {}
+func (*ModerateCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{28}
+}
+func (m *ModerateCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModerateCommentRequest.Unmarshal(m, b)
+}
+func (m *ModerateCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModerateCommentRequest.Marshal(b, m, deterministic)
+}
+func (dst *ModerateCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModerateCommentRequest.Merge(dst, src)
+}
+func (m *ModerateCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_ModerateCommentRequest.Size(m)
+}
+func (m *ModerateCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModerateCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModerateCommentRequest proto.InternalMessageInfo
+
+func (m *ModerateCommentRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ModerateCommentRequest) GetCommentId() string {
+	if m != nil {
+		return m.CommentId
+	}
+	return ""
+}
+
+func (m *ModerateCommentRequest) GetState() Comment_State {
+	if m != nil {
+		return m.State
+	}
+	return Comment_STATE_UNSPECIFIED
+}
+
+type ModerateCommentResponse struct {
+	Comment              *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ModerateCommentResponse) Reset()         { *m = ModerateCommentResponse{} }
+func (m *ModerateCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*ModerateCommentResponse) ProtoMessage()    {}
+func (*ModerateCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{29}
+}
+func (m *ModerateCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModerateCommentResponse.Unmarshal(m, b)
+}
+func (m *ModerateCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModerateCommentResponse.Marshal(b, m, deterministic)
+}
+func (dst *ModerateCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModerateCommentResponse.Merge(dst, src)
+}
+func (m *ModerateCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_ModerateCommentResponse.Size(m)
+}
+func (m *ModerateCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModerateCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModerateCommentResponse proto.InternalMessageInfo
+
+func (m *ModerateCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type ListPendingCommentsRequest struct {
+	// only list the comments of this blog when set, otherwise those of every blog not in the trash
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// maximum number of comments to return, the server picks a default when 0 and caps large values
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous ListPendingComments call, empty to start from the oldest comment
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingCommentsRequest) Reset()         { *m = ListPendingCommentsRequest{} }
+func (m *ListPendingCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPendingCommentsRequest) ProtoMessage()    {}
+func (*ListPendingCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{30}
+}
+func (m *ListPendingCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingCommentsRequest.Unmarshal(m, b)
+}
+func (m *ListPendingCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingCommentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListPendingCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingCommentsRequest.Merge(dst, src)
+}
+func (m *ListPendingCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPendingCommentsRequest.Size(m)
+}
+func (m *ListPendingCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingCommentsRequest proto.InternalMessageInfo
+
+func (m *ListPendingCommentsRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ListPendingCommentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPendingCommentsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListPendingCommentsResponse struct {
+	Comment *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	// only set on the last message of a page, empty when there are no more comments
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingCommentsResponse) Reset()         { *m = ListPendingCommentsResponse{} }
+func (m *ListPendingCommentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPendingCommentsResponse) ProtoMessage()    {}
+func (*ListPendingCommentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{31}
+}
+func (m *ListPendingCommentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingCommentsResponse.Unmarshal(m, b)
+}
+func (m *ListPendingCommentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingCommentsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListPendingCommentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingCommentsResponse.Merge(dst, src)
+}
+func (m *ListPendingCommentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPendingCommentsResponse.Size(m)
+}
+func (m *ListPendingCommentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingCommentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingCommentsResponse proto.InternalMessageInfo
+
+func (m *ListPendingCommentsResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+func (m *ListPendingCommentsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type DeleteCommentRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	CommentId            string   `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentRequest) Reset()         { *m = DeleteCommentRequest{} }
+func (m *DeleteCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentRequest) ProtoMessage()    {}
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{32}
+}
+func (m *DeleteCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentRequest.Unmarshal(m, b)
+}
+func (m *DeleteCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentRequest.Merge(dst, src)
+}
+func (m *DeleteCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentRequest.Size(m)
+}
+func (m *DeleteCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentRequest proto.InternalMessageInfo
+
+func (m *DeleteCommentRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *DeleteCommentRequest) GetCommentId() string {
+	if m != nil {
+		return m.CommentId
+	}
+	return ""
+}
+
+type DeleteCommentResponse struct {
+	CommentId            string   `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentResponse) Reset()         { *m = DeleteCommentResponse{} }
+func (m *DeleteCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentResponse) ProtoMessage()    {}
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{33}
+}
+func (m *DeleteCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentResponse.Unmarshal(m, b)
+}
+func (m *DeleteCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentResponse.Merge(dst, src)
+}
+func (m *DeleteCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentResponse.Size(m)
+}
+func (m *DeleteCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentResponse proto.InternalMessageInfo
+
+func (m *DeleteCommentResponse) GetCommentId() string {
+	if m != nil {
+		return m.CommentId
+	}
+	return ""
+}
+
+type ListTagsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTagsRequest) Reset()         { *m = ListTagsRequest{} }
+func (m *ListTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTagsRequest) ProtoMessage()    {}
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{34}
+}
+func (m *ListTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTagsRequest.Unmarshal(m, b)
+}
+func (m *ListTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTagsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsRequest.Merge(dst, src)
+}
+func (m *ListTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTagsRequest.Size(m)
+}
+func (m *ListTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTagsRequest proto.InternalMessageInfo
+
+type TagCount struct {
+	Tag string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	// number of published blogs with the tag, deleted blogs excluded
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TagCount) Reset()         { *m = TagCount{} }
+func (m *TagCount) String() string { return proto.CompactTextString(m) }
+func (*TagCount) ProtoMessage()    {}
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{35}
+}
+func (m *TagCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagCount.Unmarshal(m, b)
+}
+func (m *TagCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagCount.Marshal(b, m, deterministic)
+}
+func (dst *TagCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagCount.Merge(dst, src)
+}
+func (m *TagCount) XXX_Size() int {
+	return xxx_messageInfo_TagCount.Size(m)
+}
+func (m *TagCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagCount proto.InternalMessageInfo
+
+func (m *TagCount) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *TagCount) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	// every tag in use, the most used first
+	Tags                 []*TagCount `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListTagsResponse) Reset()         { *m = ListTagsResponse{} }
+func (m *ListTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTagsResponse) ProtoMessage()    {}
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{36}
+}
+func (m *ListTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTagsResponse.Unmarshal(m, b)
+}
+func (m *ListTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTagsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsResponse.Merge(dst, src)
+}
+func (m *ListTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTagsResponse.Size(m)
+}
+func (m *ListTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTagsResponse proto.InternalMessageInfo
+
+func (m *ListTagsResponse) GetTags() []*TagCount {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SearchBlogsRequest struct {
+	// words to look for in the title and content of blogs, a blog matches if it contains any of them.
+	// A blog must also contain every "quoted phrase", and none of the words or phrases prefixed by a minus sign.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// maximum number of results to return, the server picks a default when 0 and caps large values
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous SearchBlogs call with the same query, empty for the first page
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchBlogsRequest) Reset()         { *m = SearchBlogsRequest{} }
+func (m *SearchBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsRequest) ProtoMessage()    {}
+func (*SearchBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{37}
+}
+func (m *SearchBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsRequest.Unmarshal(m, b)
+}
+func (m *SearchBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SearchBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsRequest.Merge(dst, src)
+}
+func (m *SearchBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsRequest.Size(m)
+}
+func (m *SearchBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchBlogsRequest proto.InternalMessageInfo
+
+func (m *SearchBlogsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchBlogsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchBlogsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type SearchBlogsResponse struct {
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// relevance of the blog for the query, results come by decreasing score
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// excerpt of the blog with the matching words wrapped in <em></em>, as HTML:
+	// the text of the blog is escaped, so the snippet is safe to render and <em> is its only markup
+	Snippet string `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	// only set on the last message of a page, empty when there are no more results
+	NextPageToken        string   `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchBlogsResponse) Reset()         { *m = SearchBlogsResponse{} }
+func (m *SearchBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsResponse) ProtoMessage()    {}
+func (*SearchBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{38}
+}
+func (m *SearchBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsResponse.Unmarshal(m, b)
+}
+func (m *SearchBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsResponse.Marshal(b, m, deterministic)
+}
+func (dst *SearchBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsResponse.Merge(dst, src)
+}
+func (m *SearchBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsResponse.Size(m)
+}
+func (m *SearchBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchBlogsResponse proto.InternalMessageInfo
+
+func (m *SearchBlogsResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *SearchBlogsResponse) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchBlogsResponse) GetSnippet() string {
+	if m != nil {
+		return m.Snippet
+	}
+	return ""
+}
+
+func (m *SearchBlogsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type WatchBlogsRequest struct {
+	// only stream the changes to the blogs of this author when set
+	AuthorId string `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// resume_token of the last event received, empty to only watch the changes from now on
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchBlogsRequest) Reset()         { *m = WatchBlogsRequest{} }
+func (m *WatchBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchBlogsRequest) ProtoMessage()    {}
+func (*WatchBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{39}
+}
+func (m *WatchBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchBlogsRequest.Unmarshal(m, b)
+}
+func (m *WatchBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchBlogsRequest.Marshal(b, m, deterministic)
+}
+func (dst *WatchBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchBlogsRequest.Merge(dst, src)
+}
+func (m *WatchBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchBlogsRequest.Size(m)
+}
+func (m *WatchBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchBlogsRequest proto.InternalMessageInfo
+
+func (m *WatchBlogsRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *WatchBlogsRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type WatchBlogsResponse struct {
+	Event                *BlogEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *WatchBlogsResponse) Reset()         { *m = WatchBlogsResponse{} }
+func (m *WatchBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchBlogsResponse) ProtoMessage()    {}
+func (*WatchBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{40}
+}
+func (m *WatchBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchBlogsResponse.Unmarshal(m, b)
+}
+func (m *WatchBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchBlogsResponse.Marshal(b, m, deterministic)
+}
+func (dst *WatchBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchBlogsResponse.Merge(dst, src)
+}
+func (m *WatchBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchBlogsResponse.Size(m)
+}
+func (m *WatchBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchBlogsResponse proto.InternalMessageInfo
+
+func (m *WatchBlogsResponse) GetEvent() *BlogEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type ListBlogRequest struct {
+	// maximum number of blogs to return, the server picks a default when 0 and caps large values
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous ListBlog call, empty to start from the beginning
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// conjunction of field comparisons joined by AND, e.g. author_id = "Stephane" AND title = "My*"
+	// supported fields: author_id (exact), title (exact, or a non-empty prefix with a trailing *, \* matching a literal *)
+	// deleted (true or false, deleted = true lists the trash, only along with an author_id)
+	// and state (DRAFT, SCHEDULED, PUBLISHED or ARCHIVED, only along with an author_id)
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// sort field followed by an optional direction, e.g. "title desc"
+	// supported fields: id (creation order, the default), title, create_time and update_time
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// include deleted blogs, which are hidden by default
+	// like show_unpublished, it must come with an author_id filter matching the caller
+	ShowDeleted bool `protobuf:"varint,5,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	// only list the blogs with these tags, how they must match is set by tag_match
+	Tags     []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	TagMatch TagMatch `protobuf:"varint,7,opt,name=tag_match,json=tagMatch,proto3,enum=blog.TagMatch" json:"tag_match,omitempty"`
+	// include the blogs that are not published, which are hidden by default
+	// it must come with an author_id filter matching the caller, only authors and admins see unpublished blogs
+	ShowUnpublished      bool     `protobuf:"varint,8,opt,name=show_unpublished,json=showUnpublished,proto3" json:"show_unpublished,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogRequest) Reset()         { *m = ListBlogRequest{} }
+func (m *ListBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRequest) ProtoMessage()    {}
+func (*ListBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{41}
+}
+func (m *ListBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRequest.Unmarshal(m, b)
+}
+func (m *ListBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRequest.Merge(dst, src)
+}
+func (m *ListBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRequest.Size(m)
+}
+func (m *ListBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRequest proto.InternalMessageInfo
+
+func (m *ListBlogRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListBlogRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListBlogRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ListBlogRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ListBlogRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+func (m *ListBlogRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ListBlogRequest) GetTagMatch() TagMatch {
+	if m != nil {
+		return m.TagMatch
+	}
+	return TagMatch_TAG_MATCH_ALL
+}
+
+func (m *ListBlogRequest) GetShowUnpublished() bool {
+	if m != nil {
+		return m.ShowUnpublished
+	}
+	return false
+}
+
+type ListBlogResponse struct {
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// only set on the last message of a page, empty when there are no more blogs
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogResponse) Reset()         { *m = ListBlogResponse{} }
+func (m *ListBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBlogResponse) ProtoMessage()    {}
+func (*ListBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blog_2b2879c9b76e0236, []int{42}
+}
+func (m *ListBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogResponse.Unmarshal(m, b)
+}
+func (m *ListBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogResponse.Merge(dst, src)
+}
+func (m *ListBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBlogResponse.Size(m)
+}
+func (m *ListBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogResponse proto.InternalMessageInfo
+
+func (m *ListBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *ListBlogResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Blog)(nil), "blog.Blog")
+	proto.RegisterType((*BlogRevision)(nil), "blog.BlogRevision")
+	proto.RegisterType((*BlogEvent)(nil), "blog.BlogEvent")
+	proto.RegisterType((*Comment)(nil), "blog.Comment")
 	proto.RegisterType((*CreateBlogRequest)(nil), "blog.CreateBlogRequest")
 	proto.RegisterType((*CreateBlogResponse)(nil), "blog.CreateBlogResponse")
 	proto.RegisterType((*ReadBlogRequest)(nil), "blog.ReadBlogRequest")
@@ -467,8 +2374,41 @@ func init() {
 	proto.RegisterType((*UpdateBlogResponse)(nil), "blog.UpdateBlogResponse")
 	proto.RegisterType((*DeleteBlogRequest)(nil), "blog.DeleteBlogRequest")
 	proto.RegisterType((*DeleteBlogResponse)(nil), "blog.DeleteBlogResponse")
+	proto.RegisterType((*UndeleteBlogRequest)(nil), "blog.UndeleteBlogRequest")
+	proto.RegisterType((*UndeleteBlogResponse)(nil), "blog.UndeleteBlogResponse")
+	proto.RegisterType((*ListBlogRevisionsRequest)(nil), "blog.ListBlogRevisionsRequest")
+	proto.RegisterType((*ListBlogRevisionsResponse)(nil), "blog.ListBlogRevisionsResponse")
+	proto.RegisterType((*GetBlogRevisionRequest)(nil), "blog.GetBlogRevisionRequest")
+	proto.RegisterType((*GetBlogRevisionResponse)(nil), "blog.GetBlogRevisionResponse")
+	proto.RegisterType((*RestoreBlogRevisionRequest)(nil), "blog.RestoreBlogRevisionRequest")
+	proto.RegisterType((*RestoreBlogRevisionResponse)(nil), "blog.RestoreBlogRevisionResponse")
+	proto.RegisterType((*PublishBlogRequest)(nil), "blog.PublishBlogRequest")
+	proto.RegisterType((*PublishBlogResponse)(nil), "blog.PublishBlogResponse")
+	proto.RegisterType((*UnpublishBlogRequest)(nil), "blog.UnpublishBlogRequest")
+	proto.RegisterType((*UnpublishBlogResponse)(nil), "blog.UnpublishBlogResponse")
+	proto.RegisterType((*CreateCommentRequest)(nil), "blog.CreateCommentRequest")
+	proto.RegisterType((*CreateCommentResponse)(nil), "blog.CreateCommentResponse")
+	proto.RegisterType((*ListCommentsRequest)(nil), "blog.ListCommentsRequest")
+	proto.RegisterType((*ListCommentsResponse)(nil), "blog.ListCommentsResponse")
+	proto.RegisterType((*ModerateCommentRequest)(nil), "blog.ModerateCommentRequest")
+	proto.RegisterType((*ModerateCommentResponse)(nil), "blog.ModerateCommentResponse")
+	proto.RegisterType((*ListPendingCommentsRequest)(nil), "blog.ListPendingCommentsRequest")
+	proto.RegisterType((*ListPendingCommentsResponse)(nil), "blog.ListPendingCommentsResponse")
+	proto.RegisterType((*DeleteCommentRequest)(nil), "blog.DeleteCommentRequest")
+	proto.RegisterType((*DeleteCommentResponse)(nil), "blog.DeleteCommentResponse")
+	proto.RegisterType((*ListTagsRequest)(nil), "blog.ListTagsRequest")
+	proto.RegisterType((*TagCount)(nil), "blog.TagCount")
+	proto.RegisterType((*ListTagsResponse)(nil), "blog.ListTagsResponse")
+	proto.RegisterType((*SearchBlogsRequest)(nil), "blog.SearchBlogsRequest")
+	proto.RegisterType((*SearchBlogsResponse)(nil), "blog.SearchBlogsResponse")
+	proto.RegisterType((*WatchBlogsRequest)(nil), "blog.WatchBlogsRequest")
+	proto.RegisterType((*WatchBlogsResponse)(nil), "blog.WatchBlogsResponse")
 	proto.RegisterType((*ListBlogRequest)(nil), "blog.ListBlogRequest")
 	proto.RegisterType((*ListBlogResponse)(nil), "blog.ListBlogResponse")
+	proto.RegisterEnum("blog.TagMatch", TagMatch_name, TagMatch_value)
+	proto.RegisterEnum("blog.Blog_State", Blog_State_name, Blog_State_value)
+	proto.RegisterEnum("blog.BlogEvent_Type", BlogEvent_Type_name, BlogEvent_Type_value)
+	proto.RegisterEnum("blog.Comment_State", Comment_State_name, Comment_State_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -487,7 +2427,28 @@ type BlogServiceClient interface {
 	ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error)
 	UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
 	DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
+	UndeleteBlog(ctx context.Context, in *UndeleteBlogRequest, opts ...grpc.CallOption) (*UndeleteBlogResponse, error)
 	ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error)
+	// publishing workflow, return NOT_FOUND if not found, ABORTED on a version conflict
+	// and FAILED_PRECONDITION if the blog is already in the requested state
+	PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*PublishBlogResponse, error)
+	UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UnpublishBlogResponse, error)
+	// revision history, newest first. Revisions made while the blog was not published are only shown to its author and admins
+	ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (BlogService_ListBlogRevisionsClient, error)
+	GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*GetBlogRevisionResponse, error)
+	RestoreBlogRevision(ctx context.Context, in *RestoreBlogRevisionRequest, opts ...grpc.CallOption) (*RestoreBlogRevisionResponse, error)
+	// comments of a blog, oldest first, they are removed along with the blog when it is purged from the trash
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (BlogService_ListCommentsClient, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	// moderation queue, oldest first
+	ListPendingComments(ctx context.Context, in *ListPendingCommentsRequest, opts ...grpc.CallOption) (BlogService_ListPendingCommentsClient, error)
+	ModerateComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*ModerateCommentResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// full-text search on the title and content of published blogs, deleted blogs are left out
+	SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (BlogService_SearchBlogsClient, error)
+	// streams the changes to blogs as they happen
+	WatchBlogs(ctx context.Context, in *WatchBlogsRequest, opts ...grpc.CallOption) (BlogService_WatchBlogsClient, error)
 }
 
 type blogServiceClient struct {
@@ -507,39 +2468,280 @@ func (c *blogServiceClient) CreateBlog(ctx context.Context, in *CreateBlogReques
 	return out, nil
 }
 
-func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
-	out := new(ReadBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
+func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
+	out := new(ReadBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UpdateBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
+	out := new(DeleteBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UndeleteBlog(ctx context.Context, in *UndeleteBlogRequest, opts ...grpc.CallOption) (*UndeleteBlogResponse, error) {
+	out := new(UndeleteBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UndeleteBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[0], "/blog.BlogService/ListBlog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceListBlogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_ListBlogClient interface {
+	Recv() (*ListBlogResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceListBlogClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceListBlogClient) Recv() (*ListBlogResponse, error) {
+	m := new(ListBlogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*PublishBlogResponse, error) {
+	out := new(PublishBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/PublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UnpublishBlogResponse, error) {
+	out := new(UnpublishBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UnpublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (BlogService_ListBlogRevisionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[1], "/blog.BlogService/ListBlogRevisions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceListBlogRevisionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_ListBlogRevisionsClient interface {
+	Recv() (*ListBlogRevisionsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceListBlogRevisionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceListBlogRevisionsClient) Recv() (*ListBlogRevisionsResponse, error) {
+	m := new(ListBlogRevisionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*GetBlogRevisionResponse, error) {
+	out := new(GetBlogRevisionResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/GetBlogRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RestoreBlogRevision(ctx context.Context, in *RestoreBlogRevisionRequest, opts ...grpc.CallOption) (*RestoreBlogRevisionResponse, error) {
+	out := new(RestoreBlogRevisionResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/RestoreBlogRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error) {
+	out := new(CreateCommentResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (BlogService_ListCommentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[2], "/blog.BlogService/ListComments", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceListCommentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_ListCommentsClient interface {
+	Recv() (*ListCommentsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceListCommentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceListCommentsClient) Recv() (*ListCommentsResponse, error) {
+	m := new(ListCommentsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListPendingComments(ctx context.Context, in *ListPendingCommentsRequest, opts ...grpc.CallOption) (BlogService_ListPendingCommentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[3], "/blog.BlogService/ListPendingComments", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceListPendingCommentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_ListPendingCommentsClient interface {
+	Recv() (*ListPendingCommentsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceListPendingCommentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceListPendingCommentsClient) Recv() (*ListPendingCommentsResponse, error) {
+	m := new(ListPendingCommentsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) ModerateComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*ModerateCommentResponse, error) {
+	out := new(ModerateCommentResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ModerateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ListTags", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
-	out := new(UpdateBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/UpdateBlog", in, out, opts...)
+func (c *blogServiceClient) SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (BlogService_SearchBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[4], "/blog.BlogService/SearchBlogs", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &blogServiceSearchBlogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
-	out := new(DeleteBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlog", in, out, opts...)
-	if err != nil {
+type BlogService_SearchBlogsClient interface {
+	Recv() (*SearchBlogsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceSearchBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceSearchBlogsClient) Recv() (*SearchBlogsResponse, error) {
+	m := new(SearchBlogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[0], "/blog.BlogService/ListBlog", opts...)
+func (c *blogServiceClient) WatchBlogs(ctx context.Context, in *WatchBlogsRequest, opts ...grpc.CallOption) (BlogService_WatchBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[5], "/blog.BlogService/WatchBlogs", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &blogServiceListBlogClient{stream}
+	x := &blogServiceWatchBlogsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -549,17 +2751,17 @@ func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, o
 	return x, nil
 }
 
-type BlogService_ListBlogClient interface {
-	Recv() (*ListBlogResponse, error)
+type BlogService_WatchBlogsClient interface {
+	Recv() (*WatchBlogsResponse, error)
 	grpc.ClientStream
 }
 
-type blogServiceListBlogClient struct {
+type blogServiceWatchBlogsClient struct {
 	grpc.ClientStream
 }
 
-func (x *blogServiceListBlogClient) Recv() (*ListBlogResponse, error) {
-	m := new(ListBlogResponse)
+func (x *blogServiceWatchBlogsClient) Recv() (*WatchBlogsResponse, error) {
+	m := new(WatchBlogsResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -572,7 +2774,28 @@ type BlogServiceServer interface {
 	ReadBlog(context.Context, *ReadBlogRequest) (*ReadBlogResponse, error)
 	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
 	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
+	UndeleteBlog(context.Context, *UndeleteBlogRequest) (*UndeleteBlogResponse, error)
 	ListBlog(*ListBlogRequest, BlogService_ListBlogServer) error
+	// publishing workflow, return NOT_FOUND if not found, ABORTED on a version conflict
+	// and FAILED_PRECONDITION if the blog is already in the requested state
+	PublishBlog(context.Context, *PublishBlogRequest) (*PublishBlogResponse, error)
+	UnpublishBlog(context.Context, *UnpublishBlogRequest) (*UnpublishBlogResponse, error)
+	// revision history, newest first. Revisions made while the blog was not published are only shown to its author and admins
+	ListBlogRevisions(*ListBlogRevisionsRequest, BlogService_ListBlogRevisionsServer) error
+	GetBlogRevision(context.Context, *GetBlogRevisionRequest) (*GetBlogRevisionResponse, error)
+	RestoreBlogRevision(context.Context, *RestoreBlogRevisionRequest) (*RestoreBlogRevisionResponse, error)
+	// comments of a blog, oldest first, they are removed along with the blog when it is purged from the trash
+	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	ListComments(*ListCommentsRequest, BlogService_ListCommentsServer) error
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	// moderation queue, oldest first
+	ListPendingComments(*ListPendingCommentsRequest, BlogService_ListPendingCommentsServer) error
+	ModerateComment(context.Context, *ModerateCommentRequest) (*ModerateCommentResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// full-text search on the title and content of published blogs, deleted blogs are left out
+	SearchBlogs(*SearchBlogsRequest, BlogService_SearchBlogsServer) error
+	// streams the changes to blogs as they happen
+	WatchBlogs(*WatchBlogsRequest, BlogService_WatchBlogsServer) error
 }
 
 func RegisterBlogServiceServer(s *grpc.Server, srv BlogServiceServer) {
@@ -651,6 +2874,24 @@ func _BlogService_DeleteBlog_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlogService_UndeleteBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UndeleteBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UndeleteBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UndeleteBlog(ctx, req.(*UndeleteBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlogService_ListBlog_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListBlogRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -672,6 +2913,255 @@ func (x *blogServiceListBlogServer) Send(m *ListBlogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _BlogService_PublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).PublishBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/PublishBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).PublishBlog(ctx, req.(*PublishBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UnpublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UnpublishBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, req.(*UnpublishBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlogRevisions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBlogRevisionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).ListBlogRevisions(m, &blogServiceListBlogRevisionsServer{stream})
+}
+
+type BlogService_ListBlogRevisionsServer interface {
+	Send(*ListBlogRevisionsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceListBlogRevisionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceListBlogRevisionsServer) Send(m *ListBlogRevisionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_GetBlogRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/GetBlogRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, req.(*GetBlogRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RestoreBlogRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBlogRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RestoreBlogRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/RestoreBlogRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RestoreBlogRevision(ctx, req.(*RestoreBlogRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/CreateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListComments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCommentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).ListComments(m, &blogServiceListCommentsServer{stream})
+}
+
+type BlogService_ListCommentsServer interface {
+	Send(*ListCommentsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceListCommentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceListCommentsServer) Send(m *ListCommentsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DeleteComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListPendingComments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPendingCommentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).ListPendingComments(m, &blogServiceListPendingCommentsServer{stream})
+}
+
+type BlogService_ListPendingCommentsServer interface {
+	Send(*ListPendingCommentsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceListPendingCommentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceListPendingCommentsServer) Send(m *ListPendingCommentsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_ModerateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ModerateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ModerateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ModerateComment(ctx, req.(*ModerateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ListTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_SearchBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchBlogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).SearchBlogs(m, &blogServiceSearchBlogsServer{stream})
+}
+
+type BlogService_SearchBlogsServer interface {
+	Send(*SearchBlogsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceSearchBlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceSearchBlogsServer) Send(m *SearchBlogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_WatchBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBlogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).WatchBlogs(m, &blogServiceWatchBlogsServer{stream})
+}
+
+type BlogService_WatchBlogsServer interface {
+	Send(*WatchBlogsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceWatchBlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceWatchBlogsServer) Send(m *WatchBlogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _BlogService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "blog.BlogService",
 	HandlerType: (*BlogServiceServer)(nil),
@@ -692,6 +3182,42 @@ var _BlogService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBlog",
 			Handler:    _BlogService_DeleteBlog_Handler,
 		},
+		{
+			MethodName: "UndeleteBlog",
+			Handler:    _BlogService_UndeleteBlog_Handler,
+		},
+		{
+			MethodName: "PublishBlog",
+			Handler:    _BlogService_PublishBlog_Handler,
+		},
+		{
+			MethodName: "UnpublishBlog",
+			Handler:    _BlogService_UnpublishBlog_Handler,
+		},
+		{
+			MethodName: "GetBlogRevision",
+			Handler:    _BlogService_GetBlogRevision_Handler,
+		},
+		{
+			MethodName: "RestoreBlogRevision",
+			Handler:    _BlogService_RestoreBlogRevision_Handler,
+		},
+		{
+			MethodName: "CreateComment",
+			Handler:    _BlogService_CreateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _BlogService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "ModerateComment",
+			Handler:    _BlogService_ModerateComment_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _BlogService_ListTags_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -699,34 +3225,146 @@ var _BlogService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _BlogService_ListBlog_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListBlogRevisions",
+			Handler:       _BlogService_ListBlogRevisions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListComments",
+			Handler:       _BlogService_ListComments_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListPendingComments",
+			Handler:       _BlogService_ListPendingComments_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SearchBlogs",
+			Handler:       _BlogService_SearchBlogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBlogs",
+			Handler:       _BlogService_WatchBlogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "blog/blogpb/blog.proto",
 }
 
-func init() { proto.RegisterFile("blog/blogpb/blog.proto", fileDescriptor_blog_f36092d2f7e14931) }
-
-var fileDescriptor_blog_f36092d2f7e14931 = []byte{
-	// 352 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x4f, 0xf2, 0x40,
-	0x14, 0x0c, 0xfd, 0xf8, 0xa0, 0x0c, 0x89, 0xd8, 0x8d, 0x42, 0x83, 0x89, 0x31, 0x9c, 0x8c, 0x51,
-	0x34, 0xe0, 0xc5, 0x78, 0x20, 0x41, 0x2f, 0x24, 0x9e, 0x30, 0x5e, 0xbc, 0x10, 0x60, 0x5f, 0xb0,
-	0x49, 0xc3, 0xd6, 0x76, 0xf1, 0x37, 0xfa, 0xb3, 0xcc, 0xee, 0xb2, 0xb6, 0xb6, 0x10, 0xeb, 0x05,
-	0xfa, 0xde, 0xce, 0x7b, 0x33, 0x3b, 0x93, 0x45, 0x7b, 0x11, 0x8a, 0xd5, 0xb5, 0xfa, 0x89, 0x16,
-	0xfa, 0xaf, 0x1f, 0xc5, 0x42, 0x0a, 0x56, 0x55, 0xdf, 0xbd, 0x25, 0xaa, 0xe3, 0x50, 0xac, 0xd8,
-	0x01, 0x9c, 0x80, 0xfb, 0x95, 0xb3, 0xca, 0x79, 0x63, 0xea, 0x04, 0x9c, 0x9d, 0xa0, 0x31, 0xdf,
-	0xc8, 0x37, 0x11, 0xcf, 0x02, 0xee, 0x3b, 0xba, 0xed, 0x9a, 0xc6, 0x84, 0xb3, 0x23, 0xfc, 0x97,
-	0x81, 0x0c, 0xc9, 0xff, 0xa7, 0x0f, 0x4c, 0xc1, 0x7c, 0xd4, 0x97, 0x62, 0x2d, 0x69, 0x2d, 0xfd,
-	0xaa, 0xee, 0xdb, 0xb2, 0x37, 0x84, 0xf7, 0x10, 0xd3, 0x5c, 0x92, 0xa2, 0x9a, 0xd2, 0xfb, 0x86,
-	0x12, 0xc9, 0x4e, 0xa1, 0x15, 0x68, 0xce, 0xe6, 0x00, 0x7d, 0x2d, 0x4d, 0x03, 0x8c, 0xb2, 0x5b,
-	0xb0, 0xec, 0x50, 0x12, 0x89, 0x75, 0x42, 0xbf, 0x4e, 0x5d, 0xa0, 0x35, 0xa5, 0x39, 0xcf, 0x12,
-	0x75, 0x50, 0x57, 0x47, 0xb3, 0xef, 0xfb, 0xd5, 0x54, 0x39, 0xe1, 0xbd, 0x01, 0x0e, 0x53, 0x6c,
-	0xc9, 0xfd, 0x43, 0x78, 0x2f, 0x11, 0xff, 0xfb, 0x55, 0xb2, 0x43, 0x25, 0xa9, 0x2e, 0xe1, 0x3d,
-	0x52, 0x48, 0x3f, 0xa9, 0xf6, 0x5e, 0xe6, 0x0a, 0x2c, 0x8b, 0xde, 0x72, 0xec, 0x85, 0x7b, 0x68,
-	0x3d, 0x05, 0x89, 0xcc, 0xac, 0x56, 0x76, 0xa4, 0xad, 0x72, 0x1a, 0x07, 0x9f, 0x0e, 0x9a, 0xaa,
-	0x7c, 0xa6, 0xf8, 0x23, 0x58, 0x12, 0x1b, 0x01, 0x69, 0x68, 0xac, 0x63, 0xf0, 0x85, 0xec, 0xbb,
-	0x7e, 0xf1, 0x60, 0x4b, 0x78, 0x07, 0xd7, 0x66, 0xc2, 0x8e, 0x0d, 0x2a, 0x97, 0x67, 0xb7, 0x9d,
-	0x6f, 0x6f, 0x47, 0x47, 0x40, 0xea, 0xb2, 0xe5, 0x2e, 0x84, 0x65, 0xb9, 0x77, 0x04, 0x32, 0x02,
-	0x52, 0x0b, 0xed, 0x82, 0x42, 0x04, 0x76, 0xc1, 0x0e, 0xb7, 0xef, 0xe1, 0x5a, 0x07, 0xad, 0xf8,
-	0x9c, 0xc9, 0x56, 0x7c, 0xde, 0xe8, 0x9b, 0xca, 0xd8, 0x7d, 0xad, 0x99, 0x47, 0xba, 0xa8, 0xe9,
-	0x07, 0x3a, 0xfc, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xe4, 0xa6, 0x7b, 0x72, 0xba, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("blog/blogpb/blog.proto", fileDescriptor_blog_2b2879c9b76e0236) }
+
+var fileDescriptor_blog_2b2879c9b76e0236 = []byte{
+	// 1731 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0xdb, 0x6e, 0xdb, 0xd8,
+	0x71, 0xa9, 0xbb, 0x46, 0xbe, 0x50, 0xc7, 0xb2, 0x43, 0xd3, 0xf5, 0xae, 0x42, 0xa0, 0x5b, 0x6f,
+	0x8a, 0x2a, 0x0b, 0xb5, 0x4d, 0x51, 0x04, 0x8b, 0x54, 0x96, 0x68, 0x5b, 0xad, 0xed, 0x0a, 0x94,
+	0x94, 0x62, 0x83, 0x02, 0x02, 0x2d, 0x1e, 0xcb, 0x6c, 0x64, 0x51, 0x21, 0x29, 0xb7, 0xde, 0xbe,
+	0xef, 0x5b, 0xd1, 0xff, 0xea, 0x37, 0x14, 0xe8, 0x2f, 0xf4, 0x13, 0x8a, 0x73, 0x13, 0xaf, 0xb2,
+	0xe5, 0x6e, 0xb2, 0x2f, 0x36, 0x67, 0xe6, 0xcc, 0x7d, 0xe6, 0x9c, 0x19, 0x1b, 0xf6, 0xae, 0xa6,
+	0xce, 0xe4, 0x25, 0xf9, 0x31, 0xbf, 0xa2, 0xbf, 0x1a, 0x73, 0xd7, 0xf1, 0x1d, 0x94, 0x23, 0xdf,
+	0x6a, 0x7d, 0xe2, 0x38, 0x93, 0x29, 0x7e, 0x49, 0x71, 0x57, 0x8b, 0xeb, 0x97, 0xd7, 0x36, 0x9e,
+	0x5a, 0xa3, 0x5b, 0xd3, 0x7b, 0xcf, 0xce, 0xa9, 0x5f, 0xc4, 0x4f, 0xf8, 0xf6, 0x2d, 0xf6, 0x7c,
+	0xf3, 0x76, 0xce, 0x0e, 0x68, 0xdf, 0xe7, 0x20, 0x77, 0x3c, 0x75, 0x26, 0x68, 0x0b, 0x32, 0xb6,
+	0xa5, 0x48, 0x75, 0xe9, 0xa8, 0x6c, 0x64, 0x6c, 0x0b, 0x1d, 0x40, 0xd9, 0x5c, 0xf8, 0x37, 0x8e,
+	0x3b, 0xb2, 0x2d, 0x25, 0x43, 0xd1, 0x25, 0x86, 0xe8, 0x5a, 0xa8, 0x06, 0x79, 0xdf, 0xf6, 0xa7,
+	0x58, 0xc9, 0x52, 0x02, 0x03, 0x90, 0x02, 0xc5, 0xb1, 0x33, 0xf3, 0xf1, 0xcc, 0x57, 0x72, 0x14,
+	0x2f, 0x40, 0x42, 0xb9, 0xc3, 0xae, 0x67, 0x3b, 0x33, 0x25, 0x5f, 0x97, 0x8e, 0xb2, 0x86, 0x00,
+	0xd1, 0x6b, 0xa8, 0x8c, 0x5d, 0x6c, 0xfa, 0x78, 0x44, 0x2c, 0x53, 0x0a, 0x75, 0xe9, 0xa8, 0xd2,
+	0x54, 0x1b, 0xcc, 0xec, 0x86, 0x30, 0xbb, 0x31, 0x10, 0x66, 0x1b, 0xc0, 0x8e, 0x13, 0x04, 0x61,
+	0x5e, 0xcc, 0xad, 0x25, 0x73, 0xf1, 0x71, 0x66, 0x76, 0x5c, 0x30, 0x5b, 0x78, 0x8a, 0x05, 0x73,
+	0xe9, 0x71, 0x66, 0x76, 0x9c, 0x32, 0x23, 0xc8, 0xf9, 0xe6, 0xc4, 0x53, 0xca, 0xf5, 0xec, 0x51,
+	0xd9, 0xa0, 0xdf, 0xe8, 0x4b, 0xc8, 0x7b, 0xbe, 0xe9, 0x63, 0x05, 0xea, 0xd2, 0xd1, 0x56, 0x53,
+	0x6e, 0xd0, 0x7c, 0x91, 0xe0, 0x36, 0xfa, 0x04, 0x6f, 0x30, 0x32, 0xfa, 0x06, 0x36, 0xe6, 0x8b,
+	0xab, 0xa9, 0xed, 0xdd, 0x30, 0xcd, 0x95, 0x47, 0x35, 0x57, 0xf8, 0x79, 0x82, 0xd1, 0x86, 0x90,
+	0xa7, 0xe2, 0xd0, 0x2e, 0x54, 0xfb, 0x83, 0xd6, 0x40, 0x1f, 0x0d, 0x2f, 0xfb, 0x3d, 0xbd, 0xdd,
+	0x3d, 0xe9, 0xea, 0x1d, 0xf9, 0x33, 0x54, 0x86, 0x7c, 0xc7, 0x68, 0x9d, 0x0c, 0x64, 0x09, 0x6d,
+	0x42, 0xb9, 0xdf, 0x3e, 0xd3, 0x3b, 0xc3, 0x73, 0xbd, 0x23, 0x67, 0x08, 0xd8, 0x1b, 0x1e, 0x9f,
+	0x77, 0xfb, 0x67, 0x7a, 0x47, 0xce, 0xa2, 0x0d, 0x28, 0xb5, 0x8c, 0xf6, 0x59, 0xf7, 0xad, 0xde,
+	0x91, 0x73, 0xda, 0x7f, 0x24, 0xd8, 0x20, 0xb6, 0x1a, 0xf8, 0xce, 0xa6, 0x99, 0x79, 0x06, 0x45,
+	0xe2, 0xc0, 0x68, 0x59, 0x15, 0x05, 0x02, 0x76, 0xad, 0x70, 0x32, 0x33, 0xd1, 0x64, 0x46, 0x6a,
+	0x26, 0xbb, 0xaa, 0x66, 0x72, 0x2b, 0x6a, 0x26, 0x1f, 0xad, 0x99, 0x58, 0x72, 0x0b, 0x4f, 0x4a,
+	0xae, 0xc8, 0x4f, 0x31, 0xc8, 0x8f, 0xf6, 0x2f, 0x09, 0xca, 0xc4, 0x43, 0xfd, 0x8e, 0x88, 0x3f,
+	0x82, 0x9c, 0x7f, 0x3f, 0xc7, 0xd4, 0xb7, 0xad, 0x66, 0x2d, 0x48, 0x16, 0x25, 0x37, 0x06, 0xf7,
+	0x73, 0x6c, 0xd0, 0x13, 0xe8, 0x73, 0xa0, 0xdd, 0x46, 0x9d, 0xad, 0x34, 0x21, 0x38, 0x69, 0x50,
+	0x3c, 0x7a, 0x0e, 0x1b, 0x2e, 0xf6, 0x16, 0xb7, 0x78, 0xe4, 0x3b, 0xef, 0xf1, 0x8c, 0x3b, 0x5e,
+	0x61, 0xb8, 0x01, 0x41, 0x69, 0x3d, 0xc8, 0x11, 0x81, 0xa8, 0x06, 0xf2, 0xe0, 0xdb, 0x5e, 0x3c,
+	0x63, 0x15, 0x28, 0xb6, 0x0d, 0xbd, 0x35, 0xd0, 0x3b, 0xb2, 0x44, 0x80, 0x61, 0xaf, 0x43, 0x81,
+	0x0c, 0x01, 0x3a, 0xfa, 0xb9, 0x3e, 0xa0, 0xf9, 0xaa, 0x40, 0xd1, 0xd0, 0x2f, 0xfe, 0xc8, 0xd2,
+	0xf5, 0xef, 0x0c, 0x14, 0xdb, 0xce, 0xed, 0x2d, 0x71, 0x25, 0xde, 0xba, 0xa1, 0xcc, 0x65, 0x22,
+	0x99, 0x7b, 0x30, 0x3f, 0xab, 0xbb, 0x37, 0xd6, 0xa3, 0xf9, 0x27, 0xf5, 0xe8, 0x0b, 0xa8, 0xce,
+	0x4d, 0x17, 0xcf, 0xfc, 0xd1, 0x98, 0x99, 0x4b, 0x74, 0x17, 0xa8, 0x82, 0x6d, 0x46, 0xe0, 0x6e,
+	0xb0, 0x12, 0xb1, 0xf0, 0xdc, 0xbf, 0xa1, 0x9d, 0x9c, 0x37, 0x18, 0x80, 0xbe, 0x12, 0x7d, 0x55,
+	0xa2, 0xa9, 0xda, 0x61, 0x09, 0xe0, 0x5c, 0x91, 0xd6, 0xd2, 0x4e, 0x1f, 0xe9, 0x8d, 0x0a, 0x14,
+	0x7b, 0xfa, 0x65, 0xa7, 0x7b, 0x79, 0x2a, 0x4b, 0xb4, 0xfe, 0x7b, 0x3d, 0x83, 0x06, 0x34, 0x43,
+	0x20, 0x43, 0xff, 0xbd, 0xde, 0xa6, 0xb1, 0xd6, 0x0c, 0xa8, 0xb6, 0xa9, 0x0f, 0xac, 0x25, 0x3e,
+	0x2c, 0xb0, 0xe7, 0x2f, 0x0b, 0x41, 0x5a, 0x51, 0x08, 0x87, 0x00, 0x2e, 0x3b, 0x1a, 0x84, 0xbe,
+	0xcc, 0x31, 0x5d, 0x4b, 0xfb, 0x15, 0xa0, 0xb0, 0x4c, 0x6f, 0xee, 0xcc, 0x3c, 0xfc, 0x98, 0x50,
+	0xed, 0x05, 0x6c, 0x1b, 0xd8, 0xb4, 0xc2, 0x76, 0xac, 0xea, 0x4c, 0xad, 0x09, 0x72, 0x70, 0x76,
+	0x4d, 0xf9, 0x73, 0xa8, 0x0e, 0x69, 0xdf, 0x3c, 0xc5, 0xd3, 0xa0, 0x37, 0xc9, 0x5b, 0xa3, 0x64,
+	0x56, 0x54, 0xc4, 0x09, 0x79, 0x8e, 0x2e, 0x4c, 0xef, 0xbd, 0xe8, 0x4d, 0xf2, 0x4d, 0xe2, 0x10,
+	0xd6, 0xb8, 0xa6, 0x9d, 0x27, 0x50, 0xed, 0xd0, 0xfb, 0x77, 0x9d, 0x48, 0xac, 0xbe, 0xa3, 0xb4,
+	0x5f, 0x00, 0x0a, 0xcb, 0xe1, 0xda, 0x57, 0x86, 0xf4, 0x0c, 0x76, 0x86, 0x33, 0xeb, 0x63, 0x28,
+	0x7e, 0x05, 0xb5, 0xa8, 0xa4, 0x35, 0x1d, 0x77, 0x40, 0x39, 0xb7, 0x3d, 0x3f, 0x7c, 0x37, 0x7b,
+	0x8f, 0x9a, 0x71, 0x00, 0xe5, 0xb9, 0x39, 0xc1, 0x23, 0xcf, 0xfe, 0x0e, 0x53, 0x43, 0xf2, 0x46,
+	0x89, 0x20, 0xfa, 0xf6, 0x77, 0x98, 0xd4, 0x29, 0x25, 0x86, 0xaf, 0x2b, 0x7a, 0x9c, 0x5d, 0x56,
+	0x1e, 0xec, 0xa7, 0x28, 0xe4, 0xd6, 0x36, 0xa0, 0xe4, 0x72, 0x24, 0xb7, 0x18, 0x85, 0x2c, 0xe6,
+	0x14, 0x63, 0x79, 0x06, 0x7d, 0x09, 0xdb, 0x33, 0xfc, 0x37, 0x7f, 0x14, 0x52, 0xc8, 0x1a, 0x63,
+	0x93, 0xa0, 0x7b, 0x4b, 0xa5, 0x7f, 0x80, 0xbd, 0x53, 0x1c, 0xd1, 0xf9, 0x03, 0x42, 0xdd, 0x85,
+	0x67, 0x09, 0x61, 0xff, 0x9f, 0xfd, 0x9a, 0x0b, 0xaa, 0x81, 0x3d, 0xdf, 0x71, 0xf1, 0xc7, 0xb1,
+	0x8d, 0xbc, 0x16, 0x94, 0x45, 0x90, 0xb3, 0x94, 0x5c, 0x21, 0xb8, 0xb7, 0xdc, 0xfc, 0x6f, 0xe0,
+	0x20, 0x55, 0xe7, 0x9a, 0x05, 0xf3, 0xbd, 0x04, 0xa8, 0xc7, 0x06, 0x86, 0xb5, 0x4a, 0x36, 0x3e,
+	0x8f, 0x64, 0x9e, 0x34, 0x8f, 0x84, 0x5d, 0xcd, 0x46, 0xd3, 0xf0, 0x6b, 0xd8, 0x89, 0xd8, 0xb1,
+	0xa6, 0xfd, 0x63, 0xd2, 0x28, 0xf3, 0x27, 0x38, 0xa0, 0x40, 0xd1, 0x74, 0xc7, 0x37, 0xf6, 0x1d,
+	0xb3, 0xbd, 0x64, 0x08, 0xf0, 0x01, 0xdb, 0x7e, 0x03, 0xbb, 0x31, 0x25, 0x6b, 0x5a, 0xf7, 0x06,
+	0x6a, 0xec, 0x16, 0xe7, 0x0f, 0x90, 0xb0, 0xee, 0x67, 0xe4, 0xf9, 0xa4, 0x18, 0xce, 0xba, 0x19,
+	0x79, 0xa7, 0x0c, 0x41, 0xd5, 0x7e, 0x07, 0xbb, 0x31, 0x01, 0x5c, 0xf3, 0xda, 0x12, 0xfe, 0x02,
+	0x3b, 0xa4, 0x41, 0x39, 0xfe, 0xd3, 0x5e, 0x06, 0x13, 0xa8, 0x45, 0x75, 0x3d, 0xd1, 0xd8, 0xb5,
+	0x2f, 0x80, 0xbf, 0xc3, 0xde, 0x85, 0x63, 0x61, 0x37, 0x19, 0xd9, 0x95, 0x7e, 0x1d, 0x02, 0x84,
+	0x66, 0x0a, 0xfe, 0xde, 0x8e, 0x97, 0xd3, 0xc4, 0x72, 0x6e, 0xc8, 0x3e, 0x3a, 0x37, 0x1c, 0xc3,
+	0xb3, 0x84, 0xf2, 0xa7, 0x66, 0xe5, 0x03, 0xa8, 0x24, 0x52, 0x3d, 0x3c, 0xb3, 0xec, 0xd9, 0xe4,
+	0x47, 0x49, 0xce, 0x0c, 0x0e, 0x52, 0x55, 0x7e, 0xaa, 0x1c, 0x5d, 0x42, 0x8d, 0xbd, 0x9d, 0x1f,
+	0x27, 0x43, 0xda, 0x2b, 0xd8, 0x8d, 0xc9, 0xe3, 0x96, 0x47, 0xf9, 0xa4, 0x38, 0x5f, 0x15, 0xb6,
+	0x89, 0xdf, 0x03, 0x73, 0x22, 0xe2, 0xab, 0x35, 0xa1, 0x34, 0x30, 0x27, 0x6d, 0x67, 0x31, 0xf3,
+	0x91, 0x0c, 0x59, 0xdf, 0x9c, 0x70, 0x36, 0xf2, 0x49, 0x06, 0xcb, 0x31, 0x21, 0xf1, 0xcb, 0x98,
+	0x01, 0xda, 0x2b, 0x90, 0x03, 0x31, 0x5c, 0xb3, 0xc6, 0x17, 0x07, 0xa9, 0x9e, 0x3d, 0xaa, 0x34,
+	0xb7, 0x58, 0xc0, 0x84, 0x64, 0xbe, 0x48, 0x5c, 0x03, 0xea, 0x63, 0x72, 0xc5, 0x90, 0x6b, 0x61,
+	0x99, 0xe1, 0x1a, 0xe4, 0x3f, 0x2c, 0xb0, 0x7b, 0xcf, 0xf5, 0x32, 0xe0, 0x07, 0xa5, 0xf7, 0x1f,
+	0x12, 0xec, 0x44, 0x14, 0xad, 0x77, 0x45, 0x11, 0x4b, 0xbc, 0xb1, 0xe3, 0x32, 0x7d, 0x92, 0xc1,
+	0x00, 0x72, 0x17, 0x7a, 0x33, 0x7b, 0x3e, 0xc7, 0x3e, 0xd7, 0x24, 0xc0, 0xb4, 0xf4, 0xe7, 0xd2,
+	0xd2, 0xdf, 0x87, 0xea, 0x9f, 0x4c, 0x3f, 0xe6, 0x76, 0x64, 0xa7, 0x90, 0x62, 0x3b, 0x45, 0x7c,
+	0x35, 0xca, 0x24, 0x57, 0xa3, 0xd7, 0x80, 0xc2, 0x42, 0xb9, 0x8b, 0x3f, 0x85, 0x3c, 0xbe, 0x0b,
+	0x0a, 0x77, 0x3b, 0xb6, 0x9e, 0x19, 0x8c, 0xaa, 0xfd, 0x33, 0xc3, 0x2a, 0x21, 0xfc, 0x4c, 0x44,
+	0x22, 0x2e, 0x3d, 0x18, 0xf1, 0x4c, 0x2c, 0xe2, 0x68, 0x0f, 0x0a, 0xd7, 0xf6, 0xd4, 0xc7, 0x2e,
+	0x0f, 0x11, 0x87, 0xd0, 0x3e, 0x94, 0x1c, 0xd7, 0xc2, 0xee, 0xe8, 0xea, 0x5e, 0x2c, 0x47, 0x14,
+	0x3e, 0xbe, 0x27, 0x2e, 0x7a, 0x37, 0xce, 0x5f, 0x47, 0x6c, 0xb2, 0xb3, 0xe8, 0x76, 0x54, 0x32,
+	0x2a, 0x04, 0xc7, 0x6a, 0xdb, 0x5a, 0x2e, 0xa3, 0x85, 0xd0, 0x1f, 0x0b, 0x7e, 0x0e, 0x65, 0xdf,
+	0x9c, 0x8c, 0x6e, 0x89, 0xeb, 0x74, 0xdd, 0xd9, 0x0a, 0x15, 0xdb, 0x05, 0xc1, 0x1a, 0x25, 0x9f,
+	0x7f, 0xa1, 0xaf, 0x40, 0xa6, 0x3a, 0x16, 0xe2, 0xc5, 0xc2, 0x16, 0x5d, 0x86, 0x4a, 0xc6, 0x36,
+	0xc1, 0x0f, 0x03, 0xb4, 0xf6, 0x0e, 0xe4, 0x20, 0x20, 0x6b, 0xd6, 0xcb, 0x9a, 0xed, 0xff, 0xe2,
+	0x6b, 0xda, 0x63, 0xcc, 0xa4, 0x2a, 0x6c, 0x0e, 0x5a, 0xa7, 0xa3, 0x8b, 0xd6, 0xa0, 0x7d, 0x36,
+	0x6a, 0x9d, 0x9f, 0xcb, 0x9f, 0xc5, 0x50, 0x97, 0xdf, 0xca, 0x52, 0xf3, 0xbf, 0x00, 0x15, 0xa2,
+	0xa8, 0x8f, 0xdd, 0x3b, 0x7b, 0x8c, 0xd1, 0x1b, 0x80, 0x60, 0x05, 0x42, 0xcf, 0xf8, 0x75, 0x14,
+	0x5f, 0xb4, 0x54, 0x25, 0x49, 0xe0, 0xae, 0xfc, 0x16, 0x4a, 0x62, 0xc3, 0x41, 0xbb, 0xec, 0x54,
+	0x6c, 0x3b, 0x52, 0xf7, 0xe2, 0x68, 0xce, 0xfa, 0x06, 0x20, 0x58, 0x3b, 0x84, 0xee, 0xc4, 0xea,
+	0xa3, 0x2a, 0x49, 0x42, 0x20, 0x20, 0xd8, 0x1c, 0x84, 0x80, 0xc4, 0x4e, 0xa2, 0x2a, 0x49, 0x02,
+	0x17, 0xa0, 0xc3, 0x46, 0x78, 0x03, 0x40, 0xfb, 0x5c, 0x55, 0x72, 0xbf, 0x50, 0xd5, 0x34, 0x12,
+	0x17, 0xf3, 0x1a, 0x4a, 0x22, 0xc5, 0x22, 0x06, 0xb1, 0x1e, 0x50, 0xf7, 0xe2, 0x68, 0xc6, 0xfa,
+	0xb5, 0x84, 0x8e, 0xa1, 0x12, 0x9a, 0xc9, 0x10, 0x37, 0x36, 0x39, 0x2e, 0xaa, 0xfb, 0x29, 0x14,
+	0x6e, 0xc0, 0x19, 0x6c, 0x46, 0x66, 0x27, 0xb4, 0xb4, 0x36, 0x39, 0xb5, 0xa9, 0x07, 0xa9, 0x34,
+	0x2e, 0xe9, 0x2d, 0x54, 0x13, 0xab, 0x06, 0xfa, 0x3c, 0x6e, 0x7c, 0x74, 0xe9, 0x51, 0xbf, 0x58,
+	0x49, 0x5f, 0x7a, 0x79, 0x09, 0xdb, 0xb1, 0x05, 0x00, 0xfd, 0x84, 0x71, 0xa5, 0x2f, 0x19, 0xea,
+	0xe1, 0x0a, 0x2a, 0xb7, 0xf3, 0x1d, 0xec, 0xa4, 0x4c, 0xe4, 0xa8, 0x2e, 0x4a, 0x6d, 0xd5, 0x82,
+	0xa0, 0x3e, 0x7f, 0xe0, 0x44, 0x10, 0xcd, 0xc8, 0x3c, 0x28, 0xa2, 0x99, 0x36, 0x65, 0xaa, 0x07,
+	0xa9, 0x34, 0x2e, 0xe9, 0x14, 0x36, 0xc2, 0xb3, 0x9a, 0xa8, 0xaf, 0x94, 0x59, 0x51, 0x55, 0xd3,
+	0x48, 0xcb, 0xf0, 0x9d, 0xc1, 0x66, 0xe4, 0x5d, 0x16, 0x26, 0xa5, 0x3d, 0xfe, 0xea, 0x41, 0x2a,
+	0x8d, 0x9b, 0xf4, 0x67, 0xd8, 0x49, 0x99, 0x50, 0x44, 0xe0, 0x56, 0xcf, 0x4b, 0xea, 0xf3, 0x07,
+	0x4e, 0x84, 0xd3, 0x1c, 0x1b, 0xdb, 0x44, 0x9a, 0xd3, 0x47, 0x49, 0xf5, 0x70, 0x05, 0x35, 0xb8,
+	0x5d, 0xc4, 0x40, 0x10, 0xee, 0xac, 0xd0, 0x9c, 0xa1, 0xee, 0xc5, 0xd1, 0x9c, 0xb5, 0x03, 0x95,
+	0xd0, 0x53, 0x2d, 0xfa, 0x2a, 0x39, 0x26, 0xa8, 0xfb, 0x29, 0x94, 0xa5, 0x43, 0x2d, 0x80, 0xe0,
+	0x31, 0x14, 0x57, 0x4c, 0xe2, 0xcd, 0x55, 0x95, 0x24, 0x41, 0x88, 0x38, 0x2e, 0xbd, 0x2b, 0xb0,
+	0x7f, 0x17, 0x5c, 0x15, 0xe8, 0xe6, 0xf6, 0xcb, 0xff, 0x0d, 0x00, 0x0f, 0x48, 0xe2, 0x95, 0x44,
+	0x18, 0x00, 0x00,
 }
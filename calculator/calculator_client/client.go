@@ -2,24 +2,41 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 
 	"google.golang.org/grpc/status"
 
+	"github.com/simplesteph/grpc-go-course/auth"
 	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
 
 	"google.golang.org/grpc"
 )
 
 func main() {
+	token := flag.String("token", "", "JWT sent to the server, a development token is signed with -jwt-secret when empty")
+	user := flag.String("user", "Stephane", "subject of the development token")
+	authFlags := auth.RegisterClientFlags(flag.CommandLine)
+	flag.Parse()
 
 	fmt.Println("Calculator Client")
-	cc, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+
+	tok, err := authFlags.Token(*token, *user)
+	if err != nil {
+		log.Fatalf("Error while signing a development token: %v", err)
+	}
+	opts, err := auth.DialOptions(authFlags, tok)
+	if err != nil {
+		log.Fatalf("Error while loading certificates: %v", err)
+	}
+	cc, err := grpc.Dial("localhost:50051", opts...)
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
@@ -30,6 +47,8 @@ func main() {
 
 	// doUnary(c)
 
+	// doUnaryOverflow(c)
+
 	// doServerStreaming(c)
 
 	// doClientStreaming(c)
@@ -37,6 +56,14 @@ func main() {
 	// doBiDiStreaming(c)
 
 	doErrorUnary(c)
+
+	// doBigNumbers(c)
+
+	// doEvaluate(c)
+
+	// doSession(c)
+
+	// doIsPrime(c)
 }
 
 func doUnary(c calculatorpb.CalculatorServiceClient) {
@@ -52,6 +79,38 @@ func doUnary(c calculatorpb.CalculatorServiceClient) {
 	log.Printf("Response from Sum: %v", res.SumResult)
 }
 
+func doUnaryOverflow(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do an overflowing Sum Unary RPC...")
+	req := &calculatorpb.SumRequest{
+		FirstNumber:  math.MaxInt32,
+		SecondNumber: 1,
+	}
+	_, err := c.Sum(context.Background(), req)
+	respErr, ok := status.FromError(err)
+	if !ok || respErr.Code() != codes.OutOfRange {
+		log.Fatalf("expected an OutOfRange error from Sum RPC, got: %v", err)
+	}
+	fmt.Printf("Error message from server: %v\n", respErr.Message())
+	for _, detail := range respErr.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				fmt.Printf("Invalid field %v: %v\n", violation.GetField(), violation.GetDescription())
+			}
+		case *errdetails.ErrorInfo:
+			fmt.Printf("Reason: %v %v\n", d.GetReason(), d.GetMetadata())
+		}
+	}
+
+	// saturating instead
+	req.OverflowMode = calculatorpb.SumRequest_SATURATE
+	res, err := c.Sum(context.Background(), req)
+	if err != nil {
+		log.Fatalf("error while calling Sum RPC: %v", err)
+	}
+	log.Printf("Response from saturating Sum: %v (saturated: %v)", res.GetSumResult(), res.GetSaturated())
+}
+
 func doServerStreaming(c calculatorpb.CalculatorServiceClient) {
 	fmt.Println("Starting to do a PrimeDecomposition Server Streaming RPC...")
 	req := &calculatorpb.PrimeNumberDecompositionRequest{
@@ -170,3 +229,116 @@ func doErrorCall(c calculatorpb.CalculatorServiceClient, n int32) {
 	}
 	fmt.Printf("Result of square root of %v: %v\n", n, res.GetNumberRoot())
 }
+
+func doBigNumbers(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do BigInteger and BigRational Unary RPCs...")
+
+	intRes, err := c.BigInteger(context.Background(), &calculatorpb.BigIntegerRequest{
+		FirstNumber:  "2",
+		SecondNumber: "128",
+		Operation:    calculatorpb.BigOperation_POWER,
+	})
+	if err != nil {
+		log.Fatalf("error while calling BigInteger RPC: %v", err)
+	}
+	log.Printf("2 to the power of 128: %v", intRes.GetResult())
+
+	ratRes, err := c.BigRational(context.Background(), &calculatorpb.BigRationalRequest{
+		FirstNumber:  "1000.10",
+		SecondNumber: "3",
+		Operation:    calculatorpb.BigOperation_DIVIDE,
+		Precision:    4,
+	})
+	if err != nil {
+		log.Fatalf("error while calling BigRational RPC: %v", err)
+	}
+	log.Printf("1000.10 divided by 3: %v, about %v", ratRes.GetResult(), ratRes.GetDecimal())
+
+	// malformed numbers are rejected
+	_, err = c.BigInteger(context.Background(), &calculatorpb.BigIntegerRequest{
+		FirstNumber:  "12abc",
+		SecondNumber: "1",
+		Operation:    calculatorpb.BigOperation_SUM,
+	})
+	if respErr, ok := status.FromError(err); ok && respErr.Code() == codes.InvalidArgument {
+		fmt.Printf("Error message from server: %v\n", respErr.Message())
+	}
+}
+
+func doEvaluate(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do an Evaluate Unary RPC...")
+
+	res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{
+		Expression: "max(x, 2) * (1 + sqrt(y)) ^ 2",
+		Variables:  map[string]float64{"x": 3, "y": 16},
+	})
+	if err != nil {
+		log.Fatalf("error while calling Evaluate RPC: %v", err)
+	}
+	log.Printf("Response from Evaluate: %v", res.GetResult())
+
+	// syntax errors tell where they are
+	_, err = c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: "(1 + 2"})
+	if respErr, ok := status.FromError(err); ok && respErr.Code() == codes.InvalidArgument {
+		fmt.Printf("Error message from server: %v\n", respErr.Message())
+	}
+}
+
+func doSession(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do a Session BiDi Streaming RPC...")
+
+	stream, err := c.Session(context.Background())
+	if err != nil {
+		log.Fatalf("Error while opening stream and calling Session: %v", err)
+	}
+
+	statements := []string{"x = 3 * 4", "y = x ^ 2", "sqrt(y) + ans", "_1 * 2", "z + 1"}
+	for _, statement := range statements {
+		fmt.Printf("Sending statement: %v\n", statement)
+		if err := stream.Send(&calculatorpb.SessionRequest{Statement: statement}); err != nil {
+			log.Fatalf("Error while sending statement: %v", err)
+		}
+		// results come back in order, one per statement
+		res, err := stream.Recv()
+		if err != nil {
+			log.Fatalf("Problem while reading server stream: %v", err)
+		}
+		if res.GetError() != "" {
+			fmt.Printf("[%v] error at column %v: %v\n", res.GetNumber(), res.GetErrorColumn(), res.GetError())
+			continue
+		}
+		fmt.Printf("[%v] %v\n", res.GetNumber(), res.GetResult())
+	}
+	stream.CloseSend()
+}
+
+func doIsPrime(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do an IsPrime Unary RPC...")
+
+	// 2^127 - 1, a Mersenne prime
+	mersenne := "170141183460469231731687303715884105727"
+	res, err := c.IsPrime(context.Background(), &calculatorpb.IsPrimeRequest{BigNumber: mersenne})
+	if err != nil {
+		log.Fatalf("error while calling IsPrime RPC: %v", err)
+	}
+	log.Printf("%v is prime: %v", mersenne, res.GetIsPrime())
+
+	// numbers beyond int64 are decomposed too
+	fmt.Println("Starting to do a big PrimeDecomposition Server Streaming RPC...")
+	stream, err := c.PrimeNumberDecomposition(context.Background(), &calculatorpb.PrimeNumberDecompositionRequest{
+		BigNumber: "18446744073709551617",
+	})
+	if err != nil {
+		log.Fatalf("error while calling PrimeDecomposition RPC: %v", err)
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Something happened: %v", err)
+		}
+		fmt.Println(res.GetBigPrimeFactor())
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxBigNumberLength is the longest decimal string accepted as an operand
+	maxBigNumberLength = 10000
+	// maxBigResultBits bounds the size of results, about 315,000 decimal digits,
+	// so a single POWER cannot use up the memory of the server
+	maxBigResultBits = 1 << 20
+	// defaultBigPrecision is the number of digits after the decimal point of rational results when unset
+	defaultBigPrecision = 20
+	// maxBigPrecision is the largest number of digits after the decimal point a rational result is rounded to
+	maxBigPrecision = 10000
+)
+
+var (
+	bigIntegerPattern  = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	bigRationalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+|[0-9]+/[0-9]+)$`)
+)
+
+func (*server) BigInteger(ctx context.Context, req *calculatorpb.BigIntegerRequest) (*calculatorpb.BigIntegerResponse, error) {
+	fmt.Printf("Received BigInteger RPC: %v\n", req)
+
+	first, err := parseBigInteger("first_number", req.GetFirstNumber())
+	if err != nil {
+		return nil, err
+	}
+	second, err := parseBigInteger("second_number", req.GetSecondNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Int)
+	switch req.GetOperation() {
+	case calculatorpb.BigOperation_SUM:
+		result.Add(first, second)
+	case calculatorpb.BigOperation_SUBTRACT:
+		result.Sub(first, second)
+	case calculatorpb.BigOperation_MULTIPLY:
+		result.Mul(first, second)
+	case calculatorpb.BigOperation_DIVIDE, calculatorpb.BigOperation_MODULUS:
+		if second.Sign() == 0 {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Division by zero",
+			)
+		}
+		if req.GetOperation() == calculatorpb.BigOperation_DIVIDE {
+			result.Quo(first, second)
+		} else {
+			result.Rem(first, second)
+		}
+	case calculatorpb.BigOperation_POWER:
+		if second.Sign() < 0 {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Negative exponent %v, use BigRational for fractional results", second,
+			)
+		}
+		if err := checkPowerSize(first, second); err != nil {
+			return nil, err
+		}
+		result.Exp(first, second, nil)
+	default:
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Unknown operation %v", req.GetOperation(),
+		)
+	}
+
+	return &calculatorpb.BigIntegerResponse{
+		Result: result.String(),
+	}, nil
+}
+
+func (*server) BigRational(ctx context.Context, req *calculatorpb.BigRationalRequest) (*calculatorpb.BigRationalResponse, error) {
+	fmt.Printf("Received BigRational RPC: %v\n", req)
+
+	first, err := parseBigRational("first_number", req.GetFirstNumber())
+	if err != nil {
+		return nil, err
+	}
+	second, err := parseBigRational("second_number", req.GetSecondNumber())
+	if err != nil {
+		return nil, err
+	}
+	// 0 is what an unset precision reads as, so it stands for the default
+	precision := int(req.GetPrecision())
+	if precision == 0 {
+		precision = defaultBigPrecision
+	}
+	if precision < 0 || precision > maxBigPrecision {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Precision must be between 1 and %v, or 0 for the default of %v, got %v", maxBigPrecision, defaultBigPrecision, precision,
+		)
+	}
+
+	result := new(big.Rat)
+	switch req.GetOperation() {
+	case calculatorpb.BigOperation_SUM:
+		result.Add(first, second)
+	case calculatorpb.BigOperation_SUBTRACT:
+		result.Sub(first, second)
+	case calculatorpb.BigOperation_MULTIPLY:
+		result.Mul(first, second)
+	case calculatorpb.BigOperation_DIVIDE:
+		if second.Sign() == 0 {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Division by zero",
+			)
+		}
+		result.Quo(first, second)
+	case calculatorpb.BigOperation_MODULUS:
+		if second.Sign() == 0 {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Division by zero",
+			)
+		}
+		result = ratRem(first, second)
+	case calculatorpb.BigOperation_POWER:
+		if !second.IsInt() {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Exponent %v must be an integer", second.RatString(),
+			)
+		}
+		result, err = ratPow(first, second.Num())
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Unknown operation %v", req.GetOperation(),
+		)
+	}
+
+	decimal := result.FloatString(precision)
+	rounded, _ := new(big.Rat).SetString(decimal)
+	return &calculatorpb.BigRationalResponse{
+		Result:  result.RatString(),
+		Decimal: decimal,
+		Exact:   rounded.Cmp(result) == 0,
+	}, nil
+}
+
+// parseBigInteger reads a decimal integer sent in the given request field
+func parseBigInteger(field, value string) (*big.Int, error) {
+	if err := checkBigNumberLength(field, value); err != nil {
+		return nil, err
+	}
+	if !bigIntegerPattern.MatchString(value) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Invalid %v %q: expected a decimal integer such as -1234", field, value,
+		)
+	}
+	n, _ := new(big.Int).SetString(value, 10)
+	return n, nil
+}
+
+// parseBigRational reads a decimal number or a fraction sent in the given request field.
+// Exponents such as "1e9" are refused, as a short string could stand for a huge number.
+func parseBigRational(field, value string) (*big.Rat, error) {
+	if err := checkBigNumberLength(field, value); err != nil {
+		return nil, err
+	}
+	if !bigRationalPattern.MatchString(value) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Invalid %v %q: expected a decimal number such as -12.5 or a fraction such as 1/3", field, value,
+		)
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		// the pattern only lets zero denominators through
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Invalid %v %q: the denominator cannot be zero", field, value,
+		)
+	}
+	return r, nil
+}
+
+func checkBigNumberLength(field, value string) error {
+	if value == "" {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Missing %v", field,
+		)
+	}
+	if len(value) > maxBigNumberLength {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"%v is too long: %v characters, the maximum is %v", field, len(value), maxBigNumberLength,
+		)
+	}
+	return nil
+}
+
+// checkPowerSize refuses to raise base to exponent when the result would not fit in maxBigResultBits
+func checkPowerSize(base, exponent *big.Int) error {
+	// |base| <= 1 raised to any power is -1, 0 or 1
+	bits := new(big.Int).Abs(base).BitLen() - 1
+	if bits <= 0 {
+		return nil
+	}
+	// the result has at least bits*exponent bits
+	if !exponent.IsInt64() || exponent.Int64() > maxBigResultBits/int64(bits) {
+		return status.Errorf(
+			codes.OutOfRange,
+			"Result of the power has more than %v bits", maxBigResultBits,
+		)
+	}
+	return nil
+}
+
+// ratPow raises base to an integer exponent, a negative exponent inverting the result
+func ratPow(base *big.Rat, exponent *big.Int) (*big.Rat, error) {
+	abs := new(big.Int).Abs(exponent)
+	if err := checkPowerSize(base.Num(), abs); err != nil {
+		return nil, err
+	}
+	if err := checkPowerSize(base.Denom(), abs); err != nil {
+		return nil, err
+	}
+	if exponent.Sign() < 0 && base.Sign() == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Division by zero: 0 raised to a negative exponent",
+		)
+	}
+	num := new(big.Int).Exp(base.Num(), abs, nil)
+	denom := new(big.Int).Exp(base.Denom(), abs, nil)
+	if exponent.Sign() < 0 {
+		num, denom = denom, num
+	}
+	return new(big.Rat).SetFrac(num, denom), nil
+}
+
+// ratRem returns the remainder of the division of a by b truncated towards zero, with the sign of a,
+// the same way as big.Int.Rem does for integers
+func ratRem(a, b *big.Rat) *big.Rat {
+	quotient := new(big.Rat).Quo(a, b)
+	// the denominator is positive, so Quo truncates towards zero like the quotient does
+	truncated := new(big.Int).Quo(quotient.Num(), quotient.Denom())
+	multiple := new(big.Rat).Mul(new(big.Rat).SetInt(truncated), b)
+	return multiple.Sub(a, multiple)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBigInteger(t *testing.T) {
+	tests := []struct {
+		first, second string
+		operation     calculatorpb.BigOperation
+		want          string
+		code          codes.Code
+	}{
+		{"99999999999999999999999", "1", calculatorpb.BigOperation_SUM, "100000000000000000000000", codes.OK},
+		{"1", "99999999999999999999999", calculatorpb.BigOperation_SUBTRACT, "-99999999999999999999998", codes.OK},
+		{"-12345678901234567890", "10", calculatorpb.BigOperation_MULTIPLY, "-123456789012345678900", codes.OK},
+		{"7", "2", calculatorpb.BigOperation_DIVIDE, "3", codes.OK},
+		{"-7", "2", calculatorpb.BigOperation_DIVIDE, "-3", codes.OK},
+		{"7", "-2", calculatorpb.BigOperation_DIVIDE, "-3", codes.OK},
+		{"-7", "2", calculatorpb.BigOperation_MODULUS, "-1", codes.OK},
+		{"7", "-2", calculatorpb.BigOperation_MODULUS, "1", codes.OK},
+		{"2", "100", calculatorpb.BigOperation_POWER, "1267650600228229401496703205376", codes.OK},
+		{"-1", "99999999999999999999", calculatorpb.BigOperation_POWER, "-1", codes.OK},
+		{"2", "99999999999", calculatorpb.BigOperation_POWER, "", codes.OutOfRange},
+		{"2", "-1", calculatorpb.BigOperation_POWER, "", codes.InvalidArgument},
+		{"1", "0", calculatorpb.BigOperation_DIVIDE, "", codes.InvalidArgument},
+		{"1", "0", calculatorpb.BigOperation_MODULUS, "", codes.InvalidArgument},
+		{"1.5", "0", calculatorpb.BigOperation_SUM, "", codes.InvalidArgument},
+		{"", "0", calculatorpb.BigOperation_SUM, "", codes.InvalidArgument},
+		{"1", "2", calculatorpb.BigOperation_BIG_OPERATION_UNSPECIFIED, "", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		res, err := (&server{}).BigInteger(context.Background(), &calculatorpb.BigIntegerRequest{
+			FirstNumber:  tt.first,
+			SecondNumber: tt.second,
+			Operation:    tt.operation,
+		})
+		if status.Code(err) != tt.code || res.GetResult() != tt.want {
+			t.Errorf("BigInteger(%v %v %v) = %q, %v, want %q, %v", tt.first, tt.operation, tt.second, res.GetResult(), err, tt.want, tt.code)
+		}
+	}
+}
+
+func TestBigRational(t *testing.T) {
+	tests := []struct {
+		first, second string
+		operation     calculatorpb.BigOperation
+		precision     int32
+		want          *calculatorpb.BigRationalResponse
+		code          codes.Code
+	}{
+		{"1", "3", calculatorpb.BigOperation_DIVIDE, 5, &calculatorpb.BigRationalResponse{Result: "1/3", Decimal: "0.33333"}, codes.OK},
+		{"0.1", "0.2", calculatorpb.BigOperation_SUM, 0, &calculatorpb.BigRationalResponse{Result: "3/10", Decimal: "0.30000000000000000000", Exact: true}, codes.OK},
+		{"-7.5", "2", calculatorpb.BigOperation_MODULUS, 2, &calculatorpb.BigRationalResponse{Result: "-3/2", Decimal: "-1.50", Exact: true}, codes.OK},
+		{"7.5", "-2", calculatorpb.BigOperation_MODULUS, 2, &calculatorpb.BigRationalResponse{Result: "3/2", Decimal: "1.50", Exact: true}, codes.OK},
+		{"2/3", "-2", calculatorpb.BigOperation_POWER, 2, &calculatorpb.BigRationalResponse{Result: "9/4", Decimal: "2.25", Exact: true}, codes.OK},
+		{"0", "-2", calculatorpb.BigOperation_POWER, 2, nil, codes.InvalidArgument},
+		{"2", "1/2", calculatorpb.BigOperation_POWER, 2, nil, codes.InvalidArgument},
+		{"1", "0", calculatorpb.BigOperation_DIVIDE, 2, nil, codes.InvalidArgument},
+		{"1e9", "1", calculatorpb.BigOperation_SUM, 2, nil, codes.InvalidArgument},
+		{"1/0", "1", calculatorpb.BigOperation_SUM, 2, nil, codes.InvalidArgument},
+		{".5", "1", calculatorpb.BigOperation_SUM, -1, nil, codes.InvalidArgument},
+		{".5", "1", calculatorpb.BigOperation_SUM, maxBigPrecision + 1, nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		res, err := (&server{}).BigRational(context.Background(), &calculatorpb.BigRationalRequest{
+			FirstNumber:  tt.first,
+			SecondNumber: tt.second,
+			Operation:    tt.operation,
+			Precision:    tt.precision,
+		})
+		if status.Code(err) != tt.code {
+			t.Errorf("BigRational(%v %v %v) = %v, want %v", tt.first, tt.operation, tt.second, err, tt.code)
+			continue
+		}
+		if err == nil && (res.Result != tt.want.Result || res.Decimal != tt.want.Decimal || res.Exact != tt.want.Exact) {
+			t.Errorf("BigRational(%v %v %v) = %v, want %v", tt.first, tt.operation, tt.second, res, tt.want)
+		}
+	}
+}
@@ -0,0 +1,594 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxExpressionLength is the longest expression accepted, in bytes
+	maxExpressionLength = 4096
+	// maxExpressionDepth bounds the nesting of parentheses, function calls, signs and powers,
+	// so a hostile expression cannot exhaust the stack of the parser
+	maxExpressionDepth = 64
+)
+
+func (*server) Evaluate(ctx context.Context, req *calculatorpb.EvaluateRequest) (*calculatorpb.EvaluateResponse, error) {
+	fmt.Printf("Received Evaluate RPC: %v\n", req)
+
+	for name, value := range req.GetVariables() {
+		if !validVariableName(name) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Invalid variable name %q: expected letters, digits and underscores, not starting with a digit nor naming a function", name,
+			)
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"Invalid value %v of variable %q: expected a finite number", value, name,
+			)
+		}
+	}
+	n, err := parseExpression(req.GetExpression())
+	if err != nil {
+		return nil, expressionStatus(err)
+	}
+	result, err := n.eval(req.GetVariables())
+	if err != nil {
+		return nil, expressionStatus(err)
+	}
+	return &calculatorpb.EvaluateResponse{
+		Result: result,
+	}, nil
+}
+
+// expressionStatus turns an expressionError into a status pointing at the expression field of the request
+func expressionStatus(err error) error {
+	exprErr, ok := err.(*expressionError)
+	if !ok {
+		return status.Errorf(
+			codes.Internal,
+			"Internal error: %v", err,
+		)
+	}
+	st := status.New(
+		exprErr.Code,
+		fmt.Sprintf("Error in expression at %v", exprErr),
+	)
+	detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "expression", Description: exprErr.Error()},
+		},
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// expressionError is a parse or evaluation error at a column of the expression, counted in characters from 1
+type expressionError struct {
+	Column  int
+	Message string
+	// Code is the gRPC code the error is sent back with
+	Code codes.Code
+}
+
+func (e *expressionError) Error() string {
+	return fmt.Sprintf("column %v: %v", e.Column, e.Message)
+}
+
+func errorAt(column int, format string, args ...interface{}) *expressionError {
+	return &expressionError{Column: column, Message: fmt.Sprintf(format, args...), Code: codes.InvalidArgument}
+}
+
+// function is a function expressions can call, taking between minArgs and maxArgs arguments, -1 for no maximum
+type function struct {
+	minArgs int
+	maxArgs int
+	call    func(args []float64) (float64, string)
+}
+
+var functions = map[string]*function{
+	"sqrt": {1, 1, func(args []float64) (float64, string) {
+		if args[0] < 0 {
+			return 0, fmt.Sprintf("square root of the negative number %v", args[0])
+		}
+		return math.Sqrt(args[0]), ""
+	}},
+	"abs": {1, 1, func(args []float64) (float64, string) {
+		return math.Abs(args[0]), ""
+	}},
+	"min": {1, -1, func(args []float64) (float64, string) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result, ""
+	}},
+	"max": {1, -1, func(args []float64) (float64, string) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result, ""
+	}},
+}
+
+// validVariableName tells if name can be used as a variable in expressions
+func validVariableName(name string) bool {
+	if name == "" || functions[name] != nil {
+		return false
+	}
+	for i, r := range name {
+		if !isIdentStart(r) && (i == 0 || !isDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(r rune) bool      { return r >= '0' && r <= '9' }
+func isIdentStart(r rune) bool { return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') }
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// tokenize splits an expression into numbers, identifiers and single character operators
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	column := 0
+	for i := 0; i < len(expression); {
+		r, size := utf8.DecodeRuneInString(expression[i:])
+		column++
+		start := i
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i += size
+			continue
+		case isDigit(r) || r == '.':
+			i = scanNumber(expression, i)
+			if _, err := strconv.ParseFloat(expression[start:i], 64); err != nil {
+				return nil, errorAt(column, "invalid number %q", expression[start:i])
+			}
+			tokens = append(tokens, token{tokenNumber, expression[start:i], column})
+		case isIdentStart(r):
+			for i < len(expression) && (isIdentStart(rune(expression[i])) || isDigit(rune(expression[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, expression[start:i], column})
+		case r < utf8.RuneSelf && isOperator(byte(r)):
+			i += size
+			tokens = append(tokens, token{tokenOperator, expression[start:i], column})
+		default:
+			return nil, errorAt(column, "unexpected character %q", r)
+		}
+		// numbers and identifiers are ASCII, one column per byte
+		column += i - start - 1
+	}
+	return append(tokens, token{kind: tokenEOF, column: column + 1}), nil
+}
+
+func isOperator(c byte) bool {
+	switch c {
+	case '+', '-', '*', '/', '^', '%', '(', ')', ',', '=':
+		return true
+	}
+	return false
+}
+
+// scanNumber returns the end of the number starting at i: digits, an optional fraction and an optional exponent
+func scanNumber(s string, i int) int {
+	for i < len(s) && (isDigit(rune(s[i])) || s[i] == '.') {
+		i++
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && isDigit(rune(s[j])) {
+			for j < len(s) && isDigit(rune(s[j])) {
+				j++
+			}
+			i = j
+		}
+	}
+	return i
+}
+
+// node is a parsed expression, evaluated with the values of its variables
+type node interface {
+	eval(variables map[string]float64) (float64, error)
+}
+
+type numberNode struct {
+	value float64
+}
+
+type variableNode struct {
+	name   string
+	column int
+}
+
+type negateNode struct {
+	operand node
+}
+
+type binaryNode struct {
+	op          byte
+	left, right node
+	column      int
+}
+
+type callNode struct {
+	fn     *function
+	args   []node
+	column int
+}
+
+func (n *numberNode) eval(map[string]float64) (float64, error) {
+	return n.value, nil
+}
+
+func (n *variableNode) eval(variables map[string]float64) (float64, error) {
+	value, ok := variables[n.name]
+	if !ok {
+		return 0, errorAt(n.column, "unknown variable %v", n.name)
+	}
+	return value, nil
+}
+
+func (n *negateNode) eval(variables map[string]float64) (float64, error) {
+	value, err := n.operand.eval(variables)
+	return -value, err
+}
+
+func (n *binaryNode) eval(variables map[string]float64) (float64, error) {
+	left, err := n.left.eval(variables)
+	if err != nil {
+		return 0, err
+	}
+	right, err := n.right.eval(variables)
+	if err != nil {
+		return 0, err
+	}
+	var result float64
+	switch n.op {
+	case '+':
+		result = left + right
+	case '-':
+		result = left - right
+	case '*':
+		result = left * right
+	case '/':
+		if right == 0 {
+			return 0, errorAt(n.column, "division by zero")
+		}
+		result = left / right
+	case '%':
+		if right == 0 {
+			return 0, errorAt(n.column, "modulus by zero")
+		}
+		result = math.Mod(left, right)
+	case '^':
+		if left == 0 && right < 0 {
+			return 0, errorAt(n.column, "division by zero: 0 to the power of %v", right)
+		}
+		result = math.Pow(left, right)
+		if math.IsNaN(result) {
+			return 0, errorAt(n.column, "%v to the power of %v is not a real number", left, right)
+		}
+	}
+	if math.IsNaN(result) {
+		return 0, errorAt(n.column, "%v %c %v is not a number", left, n.op, right)
+	}
+	if math.IsInf(result, 0) {
+		return 0, &expressionError{Column: n.column, Message: "result is too large", Code: codes.OutOfRange}
+	}
+	return result, nil
+}
+
+func (n *callNode) eval(variables map[string]float64) (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(variables)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = value
+	}
+	result, problem := n.fn.call(args)
+	if problem != "" {
+		return 0, errorAt(n.column, "%v", problem)
+	}
+	return result, nil
+}
+
+// parser is a recursive descent parser of the grammar:
+//
+//	expression = term { ("+" | "-") term }
+//	term       = unary { ("*" | "/" | "%") unary }
+//	unary      = ("-" | "+") unary | power
+//	power      = primary [ "^" unary ]
+//	primary    = number | variable | function "(" expression { "," expression } ")" | "(" expression ")"
+//
+// so "^" binds tighter than signs and is right associative: -2^2 is -4 and 2^3^2 is 2^9
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// parseExpression parses a whole expression
+func parseExpression(expression string) (node, error) {
+	p, err := newParser(expression)
+	if err != nil {
+		return nil, err
+	}
+	n, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// statement is an expression, optionally assigned to a variable as in "x = 3 * 4"
+type statement struct {
+	// variable is empty for plain expressions
+	variable string
+	// column of the variable, to point at it when it cannot be assigned
+	column     int
+	expression node
+}
+
+// parseStatement parses an expression, optionally preceded by "variable ="
+func parseStatement(source string) (*statement, error) {
+	p, err := newParser(source)
+	if err != nil {
+		return nil, err
+	}
+	s := &statement{}
+	// tokens end with tokenEOF, so there is a second token whenever the first one is an identifier
+	if first := p.tokens[0]; first.kind == tokenIdent {
+		if second := p.tokens[1]; second.kind == tokenOperator && second.text == "=" {
+			s.variable = first.text
+			s.column = first.column
+			p.pos += 2
+		}
+	}
+	s.expression, err = p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newParser(expression string) (*parser, error) {
+	if len(expression) > maxExpressionLength {
+		return nil, errorAt(maxExpressionLength+1, "expression is longer than %v characters", maxExpressionLength)
+	}
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the given operators
+func (p *parser) accept(operators string) (token, bool) {
+	t := p.peek()
+	if t.kind == tokenOperator && len(t.text) == 1 {
+		for i := 0; i < len(operators); i++ {
+			if t.text[0] == operators[i] {
+				return p.next(), true
+			}
+		}
+	}
+	return t, false
+}
+
+func (p *parser) expect(operator string) error {
+	if t, ok := p.accept(operator); !ok {
+		return errorAt(t.column, "expected %q, found %v", operator, t)
+	}
+	return nil
+}
+
+func (p *parser) expectEnd() error {
+	if t := p.peek(); t.kind != tokenEOF {
+		return errorAt(t.column, "unexpected %v", t)
+	}
+	return nil
+}
+
+func (p *parser) expression() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text[0], left: left, right: right, column: op.column}
+	}
+}
+
+func (p *parser) term() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*/%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text[0], left: left, right: right, column: op.column}
+	}
+}
+
+// unary is where every nested operand goes through, so it is where the depth is limited
+func (p *parser) unary() (node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExpressionDepth {
+		return nil, errorAt(p.peek().column, "expression is nested deeper than %v levels", maxExpressionDepth)
+	}
+
+	if op, ok := p.accept("+-"); ok {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if op.text == "+" {
+			return operand, nil
+		}
+		return &negateNode{operand: operand}, nil
+	}
+	return p.power()
+}
+
+func (p *parser) power() (node, error) {
+	base, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("^")
+	if !ok {
+		return base, nil
+	}
+	exponent, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{op: '^', left: base, right: exponent, column: op.column}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		value, _ := strconv.ParseFloat(t.text, 64)
+		return &numberNode{value: value}, nil
+	case tokenIdent:
+		fn, isFunction := functions[t.text]
+		if _, ok := p.accept("("); ok {
+			if !isFunction {
+				return nil, errorAt(t.column, "unknown function %v", t.text)
+			}
+			return p.call(t, fn)
+		}
+		if isFunction {
+			return nil, errorAt(t.column, "function %v must be called, as in %v(...)", t.text, t.text)
+		}
+		return &variableNode{name: t.text, column: t.column}, nil
+	case tokenOperator:
+		if t.text == "(" {
+			n, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+	return nil, errorAt(t.column, "expected a number, a variable, a function or \"(\", found %v", t)
+}
+
+// call parses the arguments of a function whose opening parenthesis was consumed
+func (p *parser) call(name token, fn *function) (node, error) {
+	var args []node
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, errorAt(name.column, "%v takes %v, got %v", name.text, describeArgCount(fn), len(args))
+	}
+	return &callNode{fn: fn, args: args, column: name.column}, nil
+}
+
+func describeArgCount(fn *function) string {
+	arguments := "arguments"
+	if fn.minArgs == 1 && fn.maxArgs <= 1 {
+		arguments = "argument"
+	}
+	switch {
+	case fn.maxArgs < 0:
+		return fmt.Sprintf("at least %v %v", fn.minArgs, arguments)
+	case fn.minArgs == fn.maxArgs:
+		return fmt.Sprintf("%v %v", fn.minArgs, arguments)
+	default:
+		return fmt.Sprintf("%v to %v %v", fn.minArgs, fn.maxArgs, arguments)
+	}
+}
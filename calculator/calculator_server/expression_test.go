@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEvaluate(t *testing.T) {
+	variables := map[string]float64{"x": 3, "y_2": 16}
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-2^2", -4},
+		{"2^3^2", 512},
+		{"2^-1", 0.5},
+		{"0^0", 1},
+		{"--3", 3},
+		{"7 % 3", 1},
+		{"-7 % 3", -1},
+		{"max(x, 2) * (1 + sqrt(y_2)) ^ 2", 75},
+		{"min(4, x, 10) + abs(-2)", 5},
+		{"1.5e2 / .5", 300},
+		{"  x*x  ", 9},
+	}
+	for _, tt := range tests {
+		res, err := (&server{}).Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: tt.expression, Variables: variables})
+		if err != nil || res.GetResult() != tt.want {
+			t.Errorf("Evaluate(%q) = %v, %v, want %v", tt.expression, res.GetResult(), err, tt.want)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	variables := map[string]float64{"x": 3}
+	tests := []struct {
+		expression string
+		code       codes.Code
+		// part of the error message, usually the column of the error
+		want string
+	}{
+		{"1 +", codes.InvalidArgument, "column 4"},
+		{"(1 + 2", codes.InvalidArgument, "column 7"},
+		{"1 + 2)", codes.InvalidArgument, "column 6"},
+		{"2 $ 3", codes.InvalidArgument, "column 3"},
+		{"z + 1", codes.InvalidArgument, "column 1"},
+		{"1 / (x - 3)", codes.InvalidArgument, "division by zero"},
+		{"1 % 0", codes.InvalidArgument, "modulus by zero"},
+		{"0 ^ -1", codes.InvalidArgument, "division by zero"},
+		{"(-1)^0.5", codes.InvalidArgument, "column 5"},
+		{"sqrt(-1)", codes.InvalidArgument, "column 1"},
+		{"sqrt", codes.InvalidArgument, "column 1"},
+		{"foo(1)", codes.InvalidArgument, "column 1"},
+		{"max()", codes.InvalidArgument, "column 1"},
+		{"sqrt(1, 2)", codes.InvalidArgument, "column 1"},
+		{"1..2", codes.InvalidArgument, "column 1"},
+		{"2x", codes.InvalidArgument, "column 2"},
+		{"x = 3", codes.InvalidArgument, "column 3"},
+		{"1 + é", codes.InvalidArgument, "column 5"},
+		{"", codes.InvalidArgument, "column 1"},
+		{strings.Repeat("(", 100) + "1" + strings.Repeat(")", 100), codes.InvalidArgument, "nested"},
+		{strings.Repeat("-", 100) + "1", codes.InvalidArgument, "nested"},
+		{strings.Repeat("1+", 3000) + "1", codes.InvalidArgument, "longer"},
+		{"10^400", codes.OutOfRange, "too large"},
+	}
+	for _, tt := range tests {
+		_, err := (&server{}).Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: tt.expression, Variables: variables})
+		st := status.Convert(err)
+		if st.Code() != tt.code || !strings.Contains(st.Message(), tt.want) {
+			t.Errorf("Evaluate(%.20q) = %v, want %v containing %q", tt.expression, err, tt.code, tt.want)
+		}
+	}
+}
+
+func TestEvaluateNonFinite(t *testing.T) {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		req := &calculatorpb.EvaluateRequest{Expression: "x - x", Variables: map[string]float64{"x": value}}
+		if _, err := (&server{}).Evaluate(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Evaluate(x - x) with x = %v = %v, want InvalidArgument", value, err)
+		}
+	}
+
+	// every operator reports a NaN, whatever the operands it gets
+	variables := map[string]float64{"inf": math.Inf(1), "nan": math.NaN()}
+	for _, expression := range []string{"inf - inf", "inf * 0", "inf / inf", "inf % 2", "nan + 1", "1 - nan", "nan * 2", "nan / 2", "2 % nan", "nan ^ 2"} {
+		n, err := parseExpression(expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result, err := n.eval(variables); err == nil {
+			t.Errorf("%q = %v, want an error", expression, result)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxPrimeDigits is the longest big_number accepted by IsPrime and PrimeNumberDecomposition
+	maxPrimeDigits = 1000
+	// trialDivisionLimit is the bound of the small primes divided out before Pollard's rho
+	trialDivisionLimit = 1000
+	// extraMillerRabinRounds are the random rounds testing numbers too large for the fixed bases,
+	// each one letting a composite through with a probability below 1/4
+	extraMillerRabinRounds = 32
+	// maxRhoSteps bounds the work of a single decomposition of a number of up to rhoBudgetBits bits,
+	// numbers whose factors are all very large being out of reach of Pollard's rho
+	maxRhoSteps = 1 << 22
+	// rhoBudgetBits is the size up to which numbers get all of maxRhoSteps, larger numbers get
+	// proportionally fewer steps since each step multiplies numbers as long as them
+	rhoBudgetBits = 64
+	// rhoBatchSize is how many steps of Pollard's rho share a gcd, and how often the context is checked
+	rhoBatchSize = 128
+	// defaultDecompositionTimeout bounds the time PrimeNumberDecomposition spends on a number, whatever the deadline of the call
+	defaultDecompositionTimeout = 10 * time.Second
+)
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+
+	smallPrimes = primesBelow(trialDivisionLimit)
+
+	// millerRabinBases are the first 13 primes, which make the Miller–Rabin test exact below deterministicLimit
+	millerRabinBases      = []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+	deterministicLimit, _ = new(big.Int).SetString("3317044064679887385961981", 10)
+)
+
+func (*server) IsPrime(ctx context.Context, req *calculatorpb.IsPrimeRequest) (*calculatorpb.IsPrimeResponse, error) {
+	fmt.Printf("Received IsPrime RPC: %v\n", req)
+
+	n, err := requestedNumber(req.GetNumber(), req.GetBigNumber())
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.IsPrimeResponse{
+		IsPrime: isPrime(n),
+	}, nil
+}
+
+// requestedNumber returns big_number when it is set, and number otherwise
+func requestedNumber(number int64, bigNumber string) (*big.Int, error) {
+	if bigNumber == "" {
+		return big.NewInt(number), nil
+	}
+	if number != 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Set either number or big_number, not both",
+		)
+	}
+	if len(bigNumber) > maxPrimeDigits {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"big_number is too long: %v characters, the maximum is %v", len(bigNumber), maxPrimeDigits,
+		)
+	}
+	return parseBigInteger("big_number", bigNumber)
+}
+
+// primesBelow returns the primes below limit, with the sieve of Eratosthenes
+func primesBelow(limit int) []int64 {
+	composite := make([]bool, limit)
+	var primes []int64
+	for i := 2; i < limit; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, int64(i))
+		for j := i * i; j < limit; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// isPrime tells if n is prime with the Miller–Rabin test, which is exact below deterministicLimit,
+// and wrong with a probability below 4^-extraMillerRabinRounds above it
+func isPrime(n *big.Int) bool {
+	if n.Cmp(two) < 0 {
+		return false
+	}
+	mod := new(big.Int)
+	for _, p := range smallPrimes {
+		bigP := big.NewInt(p)
+		if n.Cmp(bigP) == 0 {
+			return true
+		}
+		if mod.Mod(n, bigP).Sign() == 0 {
+			return false
+		}
+	}
+
+	// n - 1 = d * 2^s with d odd
+	nMinus1 := new(big.Int).Sub(n, one)
+	s := nMinus1.TrailingZeroBits()
+	d := new(big.Int).Rsh(nMinus1, s)
+
+	for _, base := range millerRabinBases {
+		if isCompositeWitness(big.NewInt(base), n, nMinus1, d, s) {
+			return false
+		}
+	}
+	if n.Cmp(deterministicLimit) < 0 {
+		return true
+	}
+	// random bases between 2 and n-2
+	span := new(big.Int).Sub(n, big.NewInt(3))
+	for i := 0; i < extraMillerRabinRounds; i++ {
+		base, err := rand.Int(rand.Reader, span)
+		if err != nil {
+			// without randomness, the fixed bases are all we have
+			break
+		}
+		if isCompositeWitness(base.Add(base, two), n, nMinus1, d, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCompositeWitness runs one round of the Miller–Rabin test and tells if base proves n composite
+func isCompositeWitness(base, n, nMinus1, d *big.Int, s uint) bool {
+	x := new(big.Int).Exp(base, d, n)
+	if x.Cmp(one) == 0 || x.Cmp(nMinus1) == 0 {
+		return false
+	}
+	for r := uint(1); r < s; r++ {
+		x.Mul(x, x).Mod(x, n)
+		if x.Cmp(nMinus1) == 0 {
+			return false
+		}
+		if x.Cmp(one) == 0 {
+			return true
+		}
+	}
+	return true
+}
+
+// factorizer decomposes numbers into primes, giving up once it spent the step budget of the number on Pollard's rho
+type factorizer struct {
+	ctx      context.Context
+	steps    int
+	maxSteps int
+}
+
+// rhoStepBudget returns how many steps of Pollard's rho a decomposition of n may take
+func rhoStepBudget(n *big.Int) int {
+	bits := n.BitLen()
+	if bits <= rhoBudgetBits {
+		return maxRhoSteps
+	}
+	return maxRhoSteps * rhoBudgetBits / bits
+}
+
+// primeFactors returns the prime factors of n > 0 in ascending order, repeated by multiplicity
+func (f *factorizer) primeFactors(n *big.Int) ([]*big.Int, error) {
+	f.maxSteps = rhoStepBudget(n)
+	var factors []*big.Int
+	n = new(big.Int).Set(n)
+
+	// small factors are found faster by trial division
+	quotient, mod := new(big.Int), new(big.Int)
+	for _, p := range smallPrimes {
+		bigP := big.NewInt(p)
+		if new(big.Int).Mul(bigP, bigP).Cmp(n) > 0 {
+			break
+		}
+		for quotient.DivMod(n, bigP, mod); mod.Sign() == 0; quotient.DivMod(n, bigP, mod) {
+			factors = append(factors, bigP)
+			n.Set(quotient)
+		}
+	}
+
+	factors, err := f.split(n, factors)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(factors, func(i, j int) bool {
+		return factors[i].Cmp(factors[j]) < 0
+	})
+	return factors, nil
+}
+
+// split appends the prime factors of n, which has no factor below trialDivisionLimit, to factors
+func (f *factorizer) split(n *big.Int, factors []*big.Int) ([]*big.Int, error) {
+	if n.Cmp(one) == 0 {
+		return factors, nil
+	}
+	if isPrime(n) {
+		return append(factors, n), nil
+	}
+	divisor, err := f.pollardRho(n)
+	if err != nil {
+		return nil, err
+	}
+	factors, err = f.split(divisor, factors)
+	if err != nil {
+		return nil, err
+	}
+	return f.split(new(big.Int).Quo(n, divisor), factors)
+}
+
+// pollardRho returns a non-trivial divisor of the odd composite n, with Brent's variant of Pollard's rho
+func (f *factorizer) pollardRho(n *big.Int) (*big.Int, error) {
+	// rho walks in circles on squares of primes, which are easier to find directly
+	if root := new(big.Int).Sqrt(n); new(big.Int).Mul(root, root).Cmp(n) == 0 {
+		return root, nil
+	}
+
+	g, diff := new(big.Int), new(big.Int)
+	// each polynomial x^2 + c that fails, finding n itself, is replaced by the next one
+	for c := big.NewInt(1); ; c.Add(c, one) {
+		next := func(x *big.Int) {
+			x.Mul(x, x).Add(x, c).Mod(x, n)
+		}
+		x, y, ys := new(big.Int), big.NewInt(2), new(big.Int)
+		q := big.NewInt(1)
+		g.SetInt64(1)
+		for r := 1; g.Cmp(one) == 0; r *= 2 {
+			// y runs r steps ahead of x before they are compared, those steps count against the budget too
+			x.Set(y)
+			for k := 0; k < r; k += rhoBatchSize {
+				batch := batchSize(r - k)
+				if err := f.step(batch); err != nil {
+					return nil, err
+				}
+				for i := 0; i < batch; i++ {
+					next(y)
+				}
+			}
+			for k := 0; k < r && g.Cmp(one) == 0; k += rhoBatchSize {
+				batch := batchSize(r - k)
+				if err := f.step(batch); err != nil {
+					return nil, err
+				}
+				ys.Set(y)
+				for i := 0; i < batch; i++ {
+					next(y)
+					q.Mul(q, diff.Sub(x, y).Abs(diff)).Mod(q, n)
+				}
+				g.GCD(nil, nil, q, n)
+			}
+		}
+		if g.Cmp(n) == 0 {
+			// the batch overshot, walk it again one gcd at a time
+			for {
+				next(ys)
+				g.GCD(nil, nil, diff.Sub(x, ys).Abs(diff), n)
+				if g.Cmp(one) != 0 {
+					break
+				}
+			}
+		}
+		if g.Cmp(n) != 0 {
+			return new(big.Int).Set(g), nil
+		}
+	}
+}
+
+// batchSize returns how many of the remaining steps of Pollard's rho run before the next check
+func batchSize(remaining int) int {
+	if remaining < rhoBatchSize {
+		return remaining
+	}
+	return rhoBatchSize
+}
+
+// step accounts for steps of Pollard's rho, failing once the budget is spent or the call is over
+func (f *factorizer) step(steps int) error {
+	if err := f.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	f.steps += steps
+	if f.steps > f.maxSteps {
+		return status.Errorf(
+			codes.ResourceExhausted,
+			"Could not decompose the number within %v steps, its prime factors are too large", f.maxSteps,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsPrime(t *testing.T) {
+	for i := int64(-5); i < 10000; i++ {
+		n := big.NewInt(i)
+		if got, want := isPrime(n), i > 1 && n.ProbablyPrime(20); got != want {
+			t.Fatalf("isPrime(%v) = %v, want %v", i, got, want)
+		}
+	}
+	tests := []struct {
+		number string
+		want   bool
+	}{
+		// Carmichael numbers and strong pseudoprimes to several bases
+		{"561", false},
+		{"41041", false},
+		{"3215031751", false},
+		{"3825123056546413051", false},
+		{"318665857834031151167461", false},
+		{"9223372036854775783", true},
+		{"170141183460469231731687303715884105727", true},
+		{"170141183460469231731687303715884105729", false},
+	}
+	for _, tt := range tests {
+		n, _ := new(big.Int).SetString(tt.number, 10)
+		if got := isPrime(n); got != tt.want {
+			t.Errorf("isPrime(%v) = %v, want %v", tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestIsPrimeRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *calculatorpb.IsPrimeRequest
+		want bool
+		code codes.Code
+	}{
+		{"number", &calculatorpb.IsPrimeRequest{Number: 97}, true, codes.OK},
+		{"composite", &calculatorpb.IsPrimeRequest{Number: 91}, false, codes.OK},
+		{"big number", &calculatorpb.IsPrimeRequest{BigNumber: "170141183460469231731687303715884105727"}, true, codes.OK},
+		{"both numbers", &calculatorpb.IsPrimeRequest{Number: 3, BigNumber: "5"}, false, codes.InvalidArgument},
+		{"malformed big number", &calculatorpb.IsPrimeRequest{BigNumber: "5x"}, false, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		res, err := (&server{}).IsPrime(context.Background(), tt.req)
+		if status.Code(err) != tt.code || res.GetIsPrime() != tt.want {
+			t.Errorf("%v: IsPrime() = %v, %v, want %v, %v", tt.name, res.GetIsPrime(), err, tt.want, tt.code)
+		}
+	}
+}
+
+func TestPrimeFactors(t *testing.T) {
+	tests := []struct {
+		number string
+		want   []string
+	}{
+		{"1", nil},
+		{"2", []string{"2"}},
+		{"120", []string{"2", "2", "2", "3", "5"}},
+		{"121", []string{"11", "11"}},
+		{"12390392840", []string{"2", "2", "2", "5", "7", "7", "163", "38783"}},
+		{"9223372036854775807", []string{"7", "7", "73", "127", "337", "92737", "649657"}},
+		{"999999000001", []string{"999999000001"}},
+		{"1000036000099", []string{"1000003", "1000033"}},
+		{"1000000016000000063", []string{"1000000007", "1000000009"}},
+		{"1000009000027000027", []string{"1000003", "1000003", "1000003"}},
+		{"18446744073709551617", []string{"274177", "67280421310721"}},
+	}
+	for _, tt := range tests {
+		n, _ := new(big.Int).SetString(tt.number, 10)
+		factors, err := (&factorizer{ctx: context.Background()}).primeFactors(n)
+		if err != nil {
+			t.Errorf("primeFactors(%v) = %v", tt.number, err)
+			continue
+		}
+		var got []string
+		for _, factor := range factors {
+			got = append(got, factor.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("primeFactors(%v) = %v, want %v", tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestPrimeFactorsDeadline(t *testing.T) {
+	// 2^128+1 has two prime factors of 17 and 22 digits, far beyond what Pollard's rho finds in time
+	n, _ := new(big.Int).SetString("340282366920938463463374607431768211457", 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := (&factorizer{ctx: ctx}).primeFactors(n); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("primeFactors(2^128+1) = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("primeFactors(2^128+1) returned %v after the deadline", elapsed)
+	}
+
+	if testing.Short() {
+		t.Skip("skipping the step budget in short mode")
+	}
+	f := &factorizer{ctx: context.Background()}
+	if _, err := f.primeFactors(n); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("primeFactors(2^128+1) = %v, want ResourceExhausted", err)
+	}
+	if budget := rhoStepBudget(n); f.steps > budget+rhoBatchSize {
+		t.Errorf("primeFactors(2^128+1) took %v steps, more than the budget of %v", f.steps, budget)
+	}
+}
+
+func TestPrimeNumberDecompositionTimeout(t *testing.T) {
+	c := newTestClient(t, &server{decompositionTimeout: 20 * time.Millisecond})
+	decompose := func(ctx context.Context, req *calculatorpb.PrimeNumberDecompositionRequest) ([]string, error) {
+		stream, err := c.PrimeNumberDecomposition(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var factors []string
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return factors, nil
+			}
+			if err != nil {
+				return factors, err
+			}
+			factors = append(factors, res.GetBigPrimeFactor())
+		}
+	}
+
+	// small numbers are decomposed well within the timeout
+	if factors, err := decompose(context.Background(), &calculatorpb.PrimeNumberDecompositionRequest{Number: 120}); err != nil || !reflect.DeepEqual(factors, []string{"2", "2", "2", "3", "5"}) {
+		t.Errorf("PrimeNumberDecomposition(120) = %v, %v, want 2 2 2 3 5", factors, err)
+	}
+
+	// the server gives up on 2^128+1 after its timeout, even though the call has no deadline
+	hard := &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: "340282366920938463463374607431768211457"}
+	start := time.Now()
+	if _, err := decompose(context.Background(), hard); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("PrimeNumberDecomposition(2^128+1) = %v, want ResourceExhausted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("PrimeNumberDecomposition(2^128+1) returned after %v", elapsed)
+	}
+
+	// a deadline of the call shorter than the timeout is still reported as such
+	c = newTestClient(t, &server{decompositionTimeout: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := decompose(ctx, hard); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("PrimeNumberDecomposition(2^128+1) with a deadline = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestRhoStepBudget(t *testing.T) {
+	tests := []struct {
+		bits int
+		want int
+	}{
+		{1, maxRhoSteps},
+		{64, maxRhoSteps},
+		{65, maxRhoSteps * 64 / 65},
+		{128, maxRhoSteps / 2},
+		{1024, maxRhoSteps / 16},
+	}
+	for _, tt := range tests {
+		n := new(big.Int).Lsh(big.NewInt(1), uint(tt.bits-1))
+		if got := rhoStepBudget(n); got != tt.want {
+			t.Errorf("rhoStepBudget(2^%v) = %v, want %v", tt.bits-1, got, tt.want)
+		}
+	}
+}
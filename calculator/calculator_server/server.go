@@ -2,49 +2,130 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"math"
 	"net"
+	"strconv"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/simplesteph/grpc-go-course/auth"
 	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
 	"google.golang.org/grpc/reflection"
 
 	"google.golang.org/grpc"
 )
 
-type server struct{}
+type server struct {
+	// decompositionTimeout bounds PrimeNumberDecomposition, defaultDecompositionTimeout when 0
+	decompositionTimeout time.Duration
+}
 
 func (*server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
 	fmt.Printf("Received Sum RPC: %v\n", req)
 	firstNumber := req.FirstNumber
 	secondNumber := req.SecondNumber
-	sum := firstNumber + secondNumber
+	// int64 holds the sum of any two int32
+	sum := int64(firstNumber) + int64(secondNumber)
+	saturated := false
+	if sum > math.MaxInt32 || sum < math.MinInt32 {
+		if req.GetOverflowMode() != calculatorpb.SumRequest_SATURATE {
+			return nil, sumOverflowError(firstNumber, secondNumber, sum)
+		}
+		saturated = true
+		if sum > math.MaxInt32 {
+			sum = math.MaxInt32
+		} else {
+			sum = math.MinInt32
+		}
+	}
 	res := &calculatorpb.SumResponse{
-		SumResult: sum,
+		SumResult: int32(sum),
+		Saturated: saturated,
 	}
 	return res, nil
 }
 
-func (*server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
+// sumOverflowError tells the client which numbers made the sum overflow,
+// in details it can read without parsing the message
+func sumOverflowError(firstNumber, secondNumber int32, sum int64) error {
+	description := fmt.Sprintf("%v + %v = %v does not fit in an int32, between %v and %v", firstNumber, secondNumber, sum, math.MinInt32, math.MaxInt32)
+	st := status.New(
+		codes.OutOfRange,
+		fmt.Sprintf("Sum overflows int32: %v", description),
+	)
+	detailed, err := st.WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "first_number", Description: description},
+				{Field: "second_number", Description: description},
+			},
+		},
+		&errdetails.ErrorInfo{
+			Reason: "INT32_OVERFLOW",
+			Domain: "calculator.CalculatorService",
+			Metadata: map[string]string{
+				"first_number":  strconv.FormatInt(int64(firstNumber), 10),
+				"second_number": strconv.FormatInt(int64(secondNumber), 10),
+				"sum":           strconv.FormatInt(sum, 10),
+			},
+		},
+	)
+	if err != nil {
+		// the details could not be encoded, the code and message still tell what happened
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func (s *server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
 	fmt.Printf("Received PrimeNumberDecomposition RPC: %v\n", req)
 
-	number := req.GetNumber()
-	divisor := int64(2)
+	number, err := requestedNumber(req.GetNumber(), req.GetBigNumber())
+	if err != nil {
+		return err
+	}
+	if number.Sign() <= 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"Received a number that is not positive: %v", number,
+		)
+	}
 
-	for number > 1 {
-		if number%divisor == 0 {
-			stream.Send(&calculatorpb.PrimeNumberDecompositionResponse{
-				PrimeFactor: divisor,
-			})
-			number = number / divisor
-		} else {
-			divisor++
-			fmt.Printf("Divisor has increased to %v\n", divisor)
+	timeout := s.decompositionTimeout
+	if timeout == 0 {
+		timeout = defaultDecompositionTimeout
+	}
+	// the call may allow more time, or have no deadline at all
+	ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+	defer cancel()
+
+	f := &factorizer{ctx: ctx}
+	factors, err := f.primeFactors(number)
+	if err != nil {
+		if status.Code(err) == codes.DeadlineExceeded && stream.Context().Err() == nil {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				"Could not decompose the number within %v, its prime factors are too large", timeout,
+			)
+		}
+		return err
+	}
+	for _, factor := range factors {
+		res := &calculatorpb.PrimeNumberDecompositionResponse{
+			BigPrimeFactor: factor.String(),
+		}
+		if factor.IsInt64() {
+			res.PrimeFactor = factor.Int64()
+		}
+		if err := stream.Send(res); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -106,7 +187,7 @@ func (*server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootReque
 	if number < 0 {
 		return nil, status.Errorf(
 			codes.InvalidArgument,
-			fmt.Sprintf("Received a negative number: %v", number),
+			"Received a negative number: %v", number,
 		)
 	}
 	return &calculatorpb.SquareRootResponse{
@@ -115,6 +196,9 @@ func (*server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootReque
 }
 
 func main() {
+	authFlags := auth.RegisterServerFlags(flag.CommandLine)
+	flag.Parse()
+
 	fmt.Println("Calculator Server")
 
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
@@ -122,7 +206,12 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	opts, err := auth.ServerOptions(context.Background(), authFlags, auth.ReflectionMethods...)
+	if err != nil {
+		log.Fatalf("Failed setting up authentication: %v", err)
+	}
+
+	s := grpc.NewServer(opts...)
 	calculatorpb.RegisterCalculatorServiceServer(s, &server{})
 
 	// Register reflection service on gRPC server.
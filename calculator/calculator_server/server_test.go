@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves srv over an in-memory connection
+func newTestClient(t *testing.T, srv *server) calculatorpb.CalculatorServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	calculatorpb.RegisterCalculatorServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+	cc, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(dialer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return calculatorpb.NewCalculatorServiceClient(cc)
+}
+
+func TestSum(t *testing.T) {
+	c := newTestClient(t, &server{})
+	tests := []struct {
+		name      string
+		req       *calculatorpb.SumRequest
+		want      int32
+		saturated bool
+		code      codes.Code
+	}{
+		{"sum", &calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: -10}, -7, false, codes.OK},
+		{"largest sum", &calculatorpb.SumRequest{FirstNumber: math.MaxInt32 - 1, SecondNumber: 1}, math.MaxInt32, false, codes.OK},
+		{"smallest sum", &calculatorpb.SumRequest{FirstNumber: math.MinInt32 + 1, SecondNumber: -1}, math.MinInt32, false, codes.OK},
+		{"overflow", &calculatorpb.SumRequest{FirstNumber: math.MaxInt32, SecondNumber: 1}, 0, false, codes.OutOfRange},
+		{"underflow", &calculatorpb.SumRequest{FirstNumber: math.MinInt32, SecondNumber: -1}, 0, false, codes.OutOfRange},
+		{"saturated overflow", &calculatorpb.SumRequest{FirstNumber: math.MaxInt32, SecondNumber: math.MaxInt32, OverflowMode: calculatorpb.SumRequest_SATURATE}, math.MaxInt32, true, codes.OK},
+		{"saturated underflow", &calculatorpb.SumRequest{FirstNumber: math.MinInt32, SecondNumber: -1, OverflowMode: calculatorpb.SumRequest_SATURATE}, math.MinInt32, true, codes.OK},
+		{"saturate without overflow", &calculatorpb.SumRequest{FirstNumber: 1, SecondNumber: 2, OverflowMode: calculatorpb.SumRequest_SATURATE}, 3, false, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.Sum(context.Background(), tt.req)
+			if status.Code(err) != tt.code || res.GetSumResult() != tt.want || res.GetSaturated() != tt.saturated {
+				t.Errorf("Sum() = %v, %v, want %v saturated %v, %v", res, err, tt.want, tt.saturated, tt.code)
+			}
+		})
+	}
+}
+
+func TestSumOverflowDetails(t *testing.T) {
+	c := newTestClient(t, &server{})
+	_, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: math.MaxInt32, SecondNumber: 2})
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.OutOfRange {
+		t.Fatalf("Sum() = %v, want OutOfRange", err)
+	}
+
+	description := "2147483647 + 2 = 2147483649 does not fit in an int32, between -2147483648 and 2147483647"
+	wantBadRequest := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "first_number", Description: description},
+			{Field: "second_number", Description: description},
+		},
+	}
+	wantInfo := &errdetails.ErrorInfo{
+		Reason:   "INT32_OVERFLOW",
+		Domain:   "calculator.CalculatorService",
+		Metadata: map[string]string{"first_number": "2147483647", "second_number": "2", "sum": "2147483649"},
+	}
+	var badRequest, info int
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			badRequest++
+			if !proto.Equal(d, wantBadRequest) {
+				t.Errorf("BadRequest = %v, want %v", d, wantBadRequest)
+			}
+		case *errdetails.ErrorInfo:
+			info++
+			if !proto.Equal(d, wantInfo) {
+				t.Errorf("ErrorInfo = %v, want %v", d, wantInfo)
+			}
+		default:
+			t.Errorf("unexpected detail %v", detail)
+		}
+	}
+	if badRequest != 1 || info != 1 {
+		t.Errorf("Sum() sent %v BadRequest and %v ErrorInfo details, want one of each", badRequest, info)
+	}
+}
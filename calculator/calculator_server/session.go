@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+)
+
+const (
+	// maxSessionHistory is how many results a session keeps for ans and _n
+	maxSessionHistory = 100
+	// maxSessionVariables bounds the memory of a session
+	maxSessionVariables = 1000
+	// lastResultVariable holds the result of the last successful statement
+	lastResultVariable = "ans"
+)
+
+// historyVariablePattern matches the variables recalling results, such as _3
+var historyVariablePattern = regexp.MustCompile(`^_[0-9]+$`)
+
+func (*server) Session(stream calculatorpb.CalculatorService_SessionServer) error {
+	fmt.Println("Received Session RPC")
+
+	// the session only lives in this call, it is gone once the stream ends
+	s := newSession()
+	defer func() {
+		fmt.Printf("Session ended after %v statements\n", s.count)
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Error while reading client stream: %v", err)
+			return err
+		}
+		if sendErr := stream.Send(s.run(req.GetStatement())); sendErr != nil {
+			log.Printf("Error while sending data to client: %v", sendErr)
+			return sendErr
+		}
+	}
+}
+
+// session holds the variables and history of a Session stream
+type session struct {
+	// count is the number of statements run so far
+	count int64
+	// variables are the ones assigned by statements, plus ans and _n for the results in history
+	variables map[string]float64
+	// assigned is the number of variables assigned by statements
+	assigned int
+	// history holds the numbers of the statements whose result can be recalled, oldest first
+	history []int64
+}
+
+func newSession() *session {
+	return &session{variables: map[string]float64{}}
+}
+
+// run runs a statement and tells its result or why it failed
+func (s *session) run(source string) *calculatorpb.SessionResponse {
+	s.count++
+	res := &calculatorpb.SessionResponse{Number: s.count}
+
+	result, variable, err := s.execute(source)
+	if err != nil {
+		res.Error = err.Error()
+		if exprErr, ok := err.(*expressionError); ok {
+			res.Error = exprErr.Message
+			res.ErrorColumn = int32(exprErr.Column)
+		}
+		return res
+	}
+	res.Result = result
+	res.Variable = variable
+	s.remember(result)
+	return res
+}
+
+func (s *session) execute(source string) (float64, string, error) {
+	stmt, err := parseStatement(source)
+	if err != nil {
+		return 0, "", err
+	}
+	if stmt.variable != "" {
+		if err := s.checkAssignable(stmt); err != nil {
+			return 0, "", err
+		}
+	}
+	result, err := stmt.expression.eval(s.variables)
+	if err != nil {
+		return 0, "", err
+	}
+	if stmt.variable != "" {
+		if _, ok := s.variables[stmt.variable]; !ok {
+			s.assigned++
+		}
+		s.variables[stmt.variable] = result
+	}
+	return result, stmt.variable, nil
+}
+
+func (s *session) checkAssignable(stmt *statement) error {
+	if !validVariableName(stmt.variable) {
+		return errorAt(stmt.column, "%v is a function and cannot be assigned", stmt.variable)
+	}
+	if stmt.variable == lastResultVariable || historyVariablePattern.MatchString(stmt.variable) {
+		return errorAt(stmt.column, "%v recalls a previous result and cannot be assigned", stmt.variable)
+	}
+	if _, ok := s.variables[stmt.variable]; !ok && s.assigned >= maxSessionVariables {
+		return errorAt(stmt.column, "a session cannot have more than %v variables", maxSessionVariables)
+	}
+	return nil
+}
+
+// remember records the result of the current statement as ans and _n, forgetting the oldest result
+// once the history is full
+func (s *session) remember(result float64) {
+	s.variables[lastResultVariable] = result
+	s.variables[historyVariable(s.count)] = result
+	s.history = append(s.history, s.count)
+	if len(s.history) > maxSessionHistory {
+		delete(s.variables, historyVariable(s.history[0]))
+		s.history = s.history[1:]
+	}
+}
+
+func historyVariable(number int64) string {
+	return "_" + strconv.FormatInt(number, 10)
+}
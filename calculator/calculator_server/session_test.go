@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/simplesteph/grpc-go-course/calculator/calculatorpb"
+)
+
+func TestSession(t *testing.T) {
+	c := newTestClient(t, &server{})
+	stream, err := c.Session(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// statements are answered one by one and in order, an invalid one leaving the session open
+	steps := []struct {
+		statement string
+		want      *calculatorpb.SessionResponse
+	}{
+		{"x = 3 * 4", &calculatorpb.SessionResponse{Number: 1, Result: 12, Variable: "x"}},
+		{"x + 1", &calculatorpb.SessionResponse{Number: 2, Result: 13}},
+		{"x +", &calculatorpb.SessionResponse{Number: 3, Error: "found end of expression", ErrorColumn: 4}},
+		{"ans * 2", &calculatorpb.SessionResponse{Number: 4, Result: 26}},
+		{"_1 + _2", &calculatorpb.SessionResponse{Number: 5, Result: 25}},
+		{"_3", &calculatorpb.SessionResponse{Number: 6, Error: "unknown variable _3", ErrorColumn: 1}},
+		{"ans = 1", &calculatorpb.SessionResponse{Number: 7, Error: "ans recalls a previous result and cannot be assigned", ErrorColumn: 1}},
+		{"sqrt = 1", &calculatorpb.SessionResponse{Number: 8, Error: "sqrt is a function and cannot be assigned", ErrorColumn: 1}},
+		{"y = x / 0", &calculatorpb.SessionResponse{Number: 9, Error: "division by zero", ErrorColumn: 7}},
+		{"y", &calculatorpb.SessionResponse{Number: 10, Error: "unknown variable y", ErrorColumn: 1}},
+		{"x = x - 2", &calculatorpb.SessionResponse{Number: 11, Result: 10, Variable: "x"}},
+	}
+	for _, step := range steps {
+		if err := stream.Send(&calculatorpb.SessionRequest{Statement: step.statement}); err != nil {
+			t.Fatal(err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() after %q = %v", step.statement, err)
+		}
+		if res.GetNumber() != step.want.GetNumber() || res.GetResult() != step.want.GetResult() || res.GetVariable() != step.want.GetVariable() ||
+			!strings.Contains(res.GetError(), step.want.GetError()) || res.GetErrorColumn() != step.want.GetErrorColumn() ||
+			(res.GetError() == "") != (step.want.GetError() == "") {
+			t.Errorf("%q = %v, want %v", step.statement, res, step.want)
+		}
+	}
+
+	// after a half-close the server answers what it got, then ends the stream
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(&calculatorpb.SessionRequest{Statement: fmt.Sprintf("x + %v", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		res, err := stream.Recv()
+		if err != nil || res.GetNumber() != int64(12+i) || res.GetResult() != float64(10+i) {
+			t.Errorf("Recv() after the half-close = %v, %v, want statement %v = %v", res, err, 12+i, 10+i)
+		}
+	}
+	if res, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Recv() after the last answer = %v, %v, want EOF", res, err)
+	}
+
+	// every stream is a session of its own
+	other, err := c.Session(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Send(&calculatorpb.SessionRequest{Statement: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := other.Recv(); err != nil || res.GetNumber() != 1 || res.GetError() == "" {
+		t.Errorf("x in a new session = %v, %v, want an unknown variable", res, err)
+	}
+	other.CloseSend()
+}
+
+func TestSessionLimits(t *testing.T) {
+	s := newSession()
+	for i := 1; i <= maxSessionHistory+1; i++ {
+		if res := s.run(fmt.Sprint(i)); res.GetError() != "" {
+			t.Fatalf("statement %v = %v", i, res.GetError())
+		}
+	}
+	// the first result was pushed out of the history, the second one is still there
+	if res := s.run("_1"); res.GetError() == "" {
+		t.Errorf("_1 after %v statements = %v, want it forgotten", maxSessionHistory+1, res.GetResult())
+	}
+	if res := s.run("_2"); res.GetError() != "" || res.GetResult() != 2 {
+		t.Errorf("_2 = %v, %v, want 2", res.GetResult(), res.GetError())
+	}
+
+	s = newSession()
+	for i := 0; i < maxSessionVariables; i++ {
+		if res := s.run(fmt.Sprintf("v%v = %v", i, i)); res.GetError() != "" {
+			t.Fatalf("v%v = %v", i, res.GetError())
+		}
+	}
+	if res := s.run("one_more = 1"); res.GetError() == "" {
+		t.Errorf("assigning variable %v succeeded", maxSessionVariables+1)
+	}
+	if res := s.run("v0 = 42"); res.GetError() != "" || res.GetResult() != 42 {
+		t.Errorf("reassigning v0 = %v, %v, want 42", res.GetResult(), res.GetError())
+	}
+}
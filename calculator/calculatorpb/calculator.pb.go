@@ -23,19 +23,90 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// BigOperation is an arithmetic operation on arbitrary-precision numbers
+type BigOperation int32
+
+const (
+	BigOperation_BIG_OPERATION_UNSPECIFIED BigOperation = 0
+	BigOperation_SUM                       BigOperation = 1
+	BigOperation_SUBTRACT                  BigOperation = 2
+	BigOperation_MULTIPLY                  BigOperation = 3
+	// integers: the quotient truncated towards zero, like Go and C, so -7 / 2 = -3
+	// and first = second * quotient + modulus
+	BigOperation_DIVIDE BigOperation = 4
+	// the exponent is second_number, which must be an integer, and non-negative for integers
+	BigOperation_POWER BigOperation = 5
+	// the remainder of the division truncated towards zero, with the sign of first_number, so -7 % 2 = -1,
+	// like the % of Evaluate
+	BigOperation_MODULUS BigOperation = 6
+)
+
+var BigOperation_name = map[int32]string{
+	0: "BIG_OPERATION_UNSPECIFIED",
+	1: "SUM",
+	2: "SUBTRACT",
+	3: "MULTIPLY",
+	4: "DIVIDE",
+	5: "POWER",
+	6: "MODULUS",
+}
+var BigOperation_value = map[string]int32{
+	"BIG_OPERATION_UNSPECIFIED": 0,
+	"SUM":                       1,
+	"SUBTRACT":                  2,
+	"MULTIPLY":                  3,
+	"DIVIDE":                    4,
+	"POWER":                     5,
+	"MODULUS":                   6,
+}
+
+func (x BigOperation) String() string {
+	return proto.EnumName(BigOperation_name, int32(x))
+}
+func (BigOperation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{0}
+}
+
+// OverflowMode tells what to do when the sum does not fit in an int32
+type SumRequest_OverflowMode int32
+
+const (
+	// fail with OUT_OF_RANGE
+	SumRequest_ERROR SumRequest_OverflowMode = 0
+	// clamp the sum to the smallest or largest int32
+	SumRequest_SATURATE SumRequest_OverflowMode = 1
+)
+
+var SumRequest_OverflowMode_name = map[int32]string{
+	0: "ERROR",
+	1: "SATURATE",
+}
+var SumRequest_OverflowMode_value = map[string]int32{
+	"ERROR":    0,
+	"SATURATE": 1,
+}
+
+func (x SumRequest_OverflowMode) String() string {
+	return proto.EnumName(SumRequest_OverflowMode_name, int32(x))
+}
+func (SumRequest_OverflowMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{0, 0}
+}
+
 type SumRequest struct {
-	FirstNumber          int32    `protobuf:"varint,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
-	SecondNumber         int32    `protobuf:"varint,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	FirstNumber          int32                   `protobuf:"varint,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
+	SecondNumber         int32                   `protobuf:"varint,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
+	OverflowMode         SumRequest_OverflowMode `protobuf:"varint,3,opt,name=overflow_mode,json=overflowMode,proto3,enum=calculator.SumRequest_OverflowMode" json:"overflow_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *SumRequest) Reset()         { *m = SumRequest{} }
 func (m *SumRequest) String() string { return proto.CompactTextString(m) }
 func (*SumRequest) ProtoMessage()    {}
 func (*SumRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{0}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{0}
 }
 func (m *SumRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SumRequest.Unmarshal(m, b)
@@ -69,8 +140,17 @@ func (m *SumRequest) GetSecondNumber() int32 {
 	return 0
 }
 
+func (m *SumRequest) GetOverflowMode() SumRequest_OverflowMode {
+	if m != nil {
+		return m.OverflowMode
+	}
+	return SumRequest_ERROR
+}
+
 type SumResponse struct {
-	SumResult            int32    `protobuf:"varint,1,opt,name=sum_result,json=sumResult,proto3" json:"sum_result,omitempty"`
+	SumResult int32 `protobuf:"varint,1,opt,name=sum_result,json=sumResult,proto3" json:"sum_result,omitempty"`
+	// whether sum_result was clamped, with the SATURATE overflow mode
+	Saturated            bool     `protobuf:"varint,2,opt,name=saturated,proto3" json:"saturated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -80,7 +160,7 @@ func (m *SumResponse) Reset()         { *m = SumResponse{} }
 func (m *SumResponse) String() string { return proto.CompactTextString(m) }
 func (*SumResponse) ProtoMessage()    {}
 func (*SumResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{1}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{1}
 }
 func (m *SumResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SumResponse.Unmarshal(m, b)
@@ -107,8 +187,17 @@ func (m *SumResponse) GetSumResult() int32 {
 	return 0
 }
 
+func (m *SumResponse) GetSaturated() bool {
+	if m != nil {
+		return m.Saturated
+	}
+	return false
+}
+
 type PrimeNumberDecompositionRequest struct {
-	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// decimal string of a number beyond int64, of up to 1000 digits, used instead of number when set
+	BigNumber            string   `protobuf:"bytes,2,opt,name=big_number,json=bigNumber,proto3" json:"big_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -118,7 +207,7 @@ func (m *PrimeNumberDecompositionRequest) Reset()         { *m = PrimeNumberDeco
 func (m *PrimeNumberDecompositionRequest) String() string { return proto.CompactTextString(m) }
 func (*PrimeNumberDecompositionRequest) ProtoMessage()    {}
 func (*PrimeNumberDecompositionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{2}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{2}
 }
 func (m *PrimeNumberDecompositionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrimeNumberDecompositionRequest.Unmarshal(m, b)
@@ -145,8 +234,18 @@ func (m *PrimeNumberDecompositionRequest) GetNumber() int64 {
 	return 0
 }
 
+func (m *PrimeNumberDecompositionRequest) GetBigNumber() string {
+	if m != nil {
+		return m.BigNumber
+	}
+	return ""
+}
+
 type PrimeNumberDecompositionResponse struct {
-	PrimeFactor          int64    `protobuf:"varint,1,opt,name=prime_factor,json=primeFactor,proto3" json:"prime_factor,omitempty"`
+	// set when the factor fits in an int64, which is always the case for requests using number
+	PrimeFactor int64 `protobuf:"varint,1,opt,name=prime_factor,json=primeFactor,proto3" json:"prime_factor,omitempty"`
+	// decimal string of the factor
+	BigPrimeFactor       string   `protobuf:"bytes,2,opt,name=big_prime_factor,json=bigPrimeFactor,proto3" json:"big_prime_factor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -156,7 +255,7 @@ func (m *PrimeNumberDecompositionResponse) Reset()         { *m = PrimeNumberDec
 func (m *PrimeNumberDecompositionResponse) String() string { return proto.CompactTextString(m) }
 func (*PrimeNumberDecompositionResponse) ProtoMessage()    {}
 func (*PrimeNumberDecompositionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{3}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{3}
 }
 func (m *PrimeNumberDecompositionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrimeNumberDecompositionResponse.Unmarshal(m, b)
@@ -183,6 +282,99 @@ func (m *PrimeNumberDecompositionResponse) GetPrimeFactor() int64 {
 	return 0
 }
 
+func (m *PrimeNumberDecompositionResponse) GetBigPrimeFactor() string {
+	if m != nil {
+		return m.BigPrimeFactor
+	}
+	return ""
+}
+
+type IsPrimeRequest struct {
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// decimal string of a number beyond int64, of up to 1000 digits, used instead of number when set
+	BigNumber            string   `protobuf:"bytes,2,opt,name=big_number,json=bigNumber,proto3" json:"big_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsPrimeRequest) Reset()         { *m = IsPrimeRequest{} }
+func (m *IsPrimeRequest) String() string { return proto.CompactTextString(m) }
+func (*IsPrimeRequest) ProtoMessage()    {}
+func (*IsPrimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{4}
+}
+func (m *IsPrimeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsPrimeRequest.Unmarshal(m, b)
+}
+func (m *IsPrimeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsPrimeRequest.Marshal(b, m, deterministic)
+}
+func (dst *IsPrimeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsPrimeRequest.Merge(dst, src)
+}
+func (m *IsPrimeRequest) XXX_Size() int {
+	return xxx_messageInfo_IsPrimeRequest.Size(m)
+}
+func (m *IsPrimeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsPrimeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsPrimeRequest proto.InternalMessageInfo
+
+func (m *IsPrimeRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *IsPrimeRequest) GetBigNumber() string {
+	if m != nil {
+		return m.BigNumber
+	}
+	return ""
+}
+
+type IsPrimeResponse struct {
+	// exact below 3317044064679887385961981, wrong with a negligible probability above
+	IsPrime              bool     `protobuf:"varint,1,opt,name=is_prime,json=isPrime,proto3" json:"is_prime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsPrimeResponse) Reset()         { *m = IsPrimeResponse{} }
+func (m *IsPrimeResponse) String() string { return proto.CompactTextString(m) }
+func (*IsPrimeResponse) ProtoMessage()    {}
+func (*IsPrimeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{5}
+}
+func (m *IsPrimeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsPrimeResponse.Unmarshal(m, b)
+}
+func (m *IsPrimeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsPrimeResponse.Marshal(b, m, deterministic)
+}
+func (dst *IsPrimeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsPrimeResponse.Merge(dst, src)
+}
+func (m *IsPrimeResponse) XXX_Size() int {
+	return xxx_messageInfo_IsPrimeResponse.Size(m)
+}
+func (m *IsPrimeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsPrimeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsPrimeResponse proto.InternalMessageInfo
+
+func (m *IsPrimeResponse) GetIsPrime() bool {
+	if m != nil {
+		return m.IsPrime
+	}
+	return false
+}
+
 type ComputeAverageRequest struct {
 	Number               int32    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -194,7 +386,7 @@ func (m *ComputeAverageRequest) Reset()         { *m = ComputeAverageRequest{} }
 func (m *ComputeAverageRequest) String() string { return proto.CompactTextString(m) }
 func (*ComputeAverageRequest) ProtoMessage()    {}
 func (*ComputeAverageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{4}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{6}
 }
 func (m *ComputeAverageRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ComputeAverageRequest.Unmarshal(m, b)
@@ -232,7 +424,7 @@ func (m *ComputeAverageResponse) Reset()         { *m = ComputeAverageResponse{}
 func (m *ComputeAverageResponse) String() string { return proto.CompactTextString(m) }
 func (*ComputeAverageResponse) ProtoMessage()    {}
 func (*ComputeAverageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{5}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{7}
 }
 func (m *ComputeAverageResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ComputeAverageResponse.Unmarshal(m, b)
@@ -270,7 +462,7 @@ func (m *FindMaximumRequest) Reset()         { *m = FindMaximumRequest{} }
 func (m *FindMaximumRequest) String() string { return proto.CompactTextString(m) }
 func (*FindMaximumRequest) ProtoMessage()    {}
 func (*FindMaximumRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{6}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{8}
 }
 func (m *FindMaximumRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindMaximumRequest.Unmarshal(m, b)
@@ -308,7 +500,7 @@ func (m *FindMaximumResponse) Reset()         { *m = FindMaximumResponse{} }
 func (m *FindMaximumResponse) String() string { return proto.CompactTextString(m) }
 func (*FindMaximumResponse) ProtoMessage()    {}
 func (*FindMaximumResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{7}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{9}
 }
 func (m *FindMaximumResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindMaximumResponse.Unmarshal(m, b)
@@ -346,7 +538,7 @@ func (m *SquareRootRequest) Reset()         { *m = SquareRootRequest{} }
 func (m *SquareRootRequest) String() string { return proto.CompactTextString(m) }
 func (*SquareRootRequest) ProtoMessage()    {}
 func (*SquareRootRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{8}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{10}
 }
 func (m *SquareRootRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SquareRootRequest.Unmarshal(m, b)
@@ -384,7 +576,7 @@ func (m *SquareRootResponse) Reset()         { *m = SquareRootResponse{} }
 func (m *SquareRootResponse) String() string { return proto.CompactTextString(m) }
 func (*SquareRootResponse) ProtoMessage()    {}
 func (*SquareRootResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0799ea845ca306d9, []int{9}
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{11}
 }
 func (m *SquareRootResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SquareRootResponse.Unmarshal(m, b)
@@ -411,17 +603,447 @@ func (m *SquareRootResponse) GetNumberRoot() float64 {
 	return 0
 }
 
+// numbers are decimal strings, such as "-123456789012345678901234567890"
+type BigIntegerRequest struct {
+	FirstNumber          string       `protobuf:"bytes,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
+	SecondNumber         string       `protobuf:"bytes,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
+	Operation            BigOperation `protobuf:"varint,3,opt,name=operation,proto3,enum=calculator.BigOperation" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *BigIntegerRequest) Reset()         { *m = BigIntegerRequest{} }
+func (m *BigIntegerRequest) String() string { return proto.CompactTextString(m) }
+func (*BigIntegerRequest) ProtoMessage()    {}
+func (*BigIntegerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{12}
+}
+func (m *BigIntegerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigIntegerRequest.Unmarshal(m, b)
+}
+func (m *BigIntegerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigIntegerRequest.Marshal(b, m, deterministic)
+}
+func (dst *BigIntegerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigIntegerRequest.Merge(dst, src)
+}
+func (m *BigIntegerRequest) XXX_Size() int {
+	return xxx_messageInfo_BigIntegerRequest.Size(m)
+}
+func (m *BigIntegerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigIntegerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigIntegerRequest proto.InternalMessageInfo
+
+func (m *BigIntegerRequest) GetFirstNumber() string {
+	if m != nil {
+		return m.FirstNumber
+	}
+	return ""
+}
+
+func (m *BigIntegerRequest) GetSecondNumber() string {
+	if m != nil {
+		return m.SecondNumber
+	}
+	return ""
+}
+
+func (m *BigIntegerRequest) GetOperation() BigOperation {
+	if m != nil {
+		return m.Operation
+	}
+	return BigOperation_BIG_OPERATION_UNSPECIFIED
+}
+
+type BigIntegerResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigIntegerResponse) Reset()         { *m = BigIntegerResponse{} }
+func (m *BigIntegerResponse) String() string { return proto.CompactTextString(m) }
+func (*BigIntegerResponse) ProtoMessage()    {}
+func (*BigIntegerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{13}
+}
+func (m *BigIntegerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigIntegerResponse.Unmarshal(m, b)
+}
+func (m *BigIntegerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigIntegerResponse.Marshal(b, m, deterministic)
+}
+func (dst *BigIntegerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigIntegerResponse.Merge(dst, src)
+}
+func (m *BigIntegerResponse) XXX_Size() int {
+	return xxx_messageInfo_BigIntegerResponse.Size(m)
+}
+func (m *BigIntegerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigIntegerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigIntegerResponse proto.InternalMessageInfo
+
+func (m *BigIntegerResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+// numbers are decimal strings, such as "-12.5", or fractions, such as "1/3"
+type BigRationalRequest struct {
+	FirstNumber  string       `protobuf:"bytes,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
+	SecondNumber string       `protobuf:"bytes,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
+	Operation    BigOperation `protobuf:"varint,3,opt,name=operation,proto3,enum=calculator.BigOperation" json:"operation,omitempty"`
+	// digits after the decimal point of the decimal result, from 1 to 10000
+	// 0, the value of an unset precision, stands for the default of 20, so a result cannot be rounded to an integer
+	Precision            int32    `protobuf:"varint,4,opt,name=precision,proto3" json:"precision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigRationalRequest) Reset()         { *m = BigRationalRequest{} }
+func (m *BigRationalRequest) String() string { return proto.CompactTextString(m) }
+func (*BigRationalRequest) ProtoMessage()    {}
+func (*BigRationalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{14}
+}
+func (m *BigRationalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigRationalRequest.Unmarshal(m, b)
+}
+func (m *BigRationalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigRationalRequest.Marshal(b, m, deterministic)
+}
+func (dst *BigRationalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigRationalRequest.Merge(dst, src)
+}
+func (m *BigRationalRequest) XXX_Size() int {
+	return xxx_messageInfo_BigRationalRequest.Size(m)
+}
+func (m *BigRationalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigRationalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigRationalRequest proto.InternalMessageInfo
+
+func (m *BigRationalRequest) GetFirstNumber() string {
+	if m != nil {
+		return m.FirstNumber
+	}
+	return ""
+}
+
+func (m *BigRationalRequest) GetSecondNumber() string {
+	if m != nil {
+		return m.SecondNumber
+	}
+	return ""
+}
+
+func (m *BigRationalRequest) GetOperation() BigOperation {
+	if m != nil {
+		return m.Operation
+	}
+	return BigOperation_BIG_OPERATION_UNSPECIFIED
+}
+
+func (m *BigRationalRequest) GetPrecision() int32 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+type BigRationalResponse struct {
+	// exact result as a reduced fraction, such as "1/3", or an integer when the denominator is 1
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// result rounded to precision digits after the decimal point
+	Decimal string `protobuf:"bytes,2,opt,name=decimal,proto3" json:"decimal,omitempty"`
+	// whether decimal is exactly the result
+	Exact                bool     `protobuf:"varint,3,opt,name=exact,proto3" json:"exact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigRationalResponse) Reset()         { *m = BigRationalResponse{} }
+func (m *BigRationalResponse) String() string { return proto.CompactTextString(m) }
+func (*BigRationalResponse) ProtoMessage()    {}
+func (*BigRationalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{15}
+}
+func (m *BigRationalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigRationalResponse.Unmarshal(m, b)
+}
+func (m *BigRationalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigRationalResponse.Marshal(b, m, deterministic)
+}
+func (dst *BigRationalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigRationalResponse.Merge(dst, src)
+}
+func (m *BigRationalResponse) XXX_Size() int {
+	return xxx_messageInfo_BigRationalResponse.Size(m)
+}
+func (m *BigRationalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigRationalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigRationalResponse proto.InternalMessageInfo
+
+func (m *BigRationalResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *BigRationalResponse) GetDecimal() string {
+	if m != nil {
+		return m.Decimal
+	}
+	return ""
+}
+
+func (m *BigRationalResponse) GetExact() bool {
+	if m != nil {
+		return m.Exact
+	}
+	return false
+}
+
+type EvaluateRequest struct {
+	// infix expression such as "max(x, 2) * (1 + sqrt(y)) ^ 2", with the operators + - * / % ^,
+	// parentheses, signs and the functions sqrt, abs, min and max
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// values of the variables the expression uses, which must be finite
+	Variables            map[string]float64 `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *EvaluateRequest) Reset()         { *m = EvaluateRequest{} }
+func (m *EvaluateRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateRequest) ProtoMessage()    {}
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{16}
+}
+func (m *EvaluateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateRequest.Unmarshal(m, b)
+}
+func (m *EvaluateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateRequest.Marshal(b, m, deterministic)
+}
+func (dst *EvaluateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateRequest.Merge(dst, src)
+}
+func (m *EvaluateRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateRequest.Size(m)
+}
+func (m *EvaluateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateRequest proto.InternalMessageInfo
+
+func (m *EvaluateRequest) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *EvaluateRequest) GetVariables() map[string]float64 {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	Result               float64  `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()    {}
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{17}
+}
+func (m *EvaluateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateResponse.Unmarshal(m, b)
+}
+func (m *EvaluateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateResponse.Marshal(b, m, deterministic)
+}
+func (dst *EvaluateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateResponse.Merge(dst, src)
+}
+func (m *EvaluateResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateResponse.Size(m)
+}
+func (m *EvaluateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateResponse proto.InternalMessageInfo
+
+func (m *EvaluateResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+type SessionRequest struct {
+	// an expression, such as "x * 2", or an assignment, such as "x = 3 * 4", in the syntax of Evaluate
+	// ans is the result of the last successful statement and _n the result of statement n,
+	// for the last 100 successful statements
+	Statement            string   `protobuf:"bytes,1,opt,name=statement,proto3" json:"statement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionRequest) Reset()         { *m = SessionRequest{} }
+func (m *SessionRequest) String() string { return proto.CompactTextString(m) }
+func (*SessionRequest) ProtoMessage()    {}
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{18}
+}
+func (m *SessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionRequest.Unmarshal(m, b)
+}
+func (m *SessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionRequest.Marshal(b, m, deterministic)
+}
+func (dst *SessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRequest.Merge(dst, src)
+}
+func (m *SessionRequest) XXX_Size() int {
+	return xxx_messageInfo_SessionRequest.Size(m)
+}
+func (m *SessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionRequest proto.InternalMessageInfo
+
+func (m *SessionRequest) GetStatement() string {
+	if m != nil {
+		return m.Statement
+	}
+	return ""
+}
+
+type SessionResponse struct {
+	// number of the statement in the session, from 1
+	Number int64   `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Result float64 `protobuf:"fixed64,2,opt,name=result,proto3" json:"result,omitempty"`
+	// variable the statement assigned, empty for expressions
+	Variable string `protobuf:"bytes,3,opt,name=variable,proto3" json:"variable,omitempty"`
+	// why the statement failed, the session going on, empty when it succeeded
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// column of the error in the statement, from 1
+	ErrorColumn          int32    `protobuf:"varint,5,opt,name=error_column,json=errorColumn,proto3" json:"error_column,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionResponse) Reset()         { *m = SessionResponse{} }
+func (m *SessionResponse) String() string { return proto.CompactTextString(m) }
+func (*SessionResponse) ProtoMessage()    {}
+func (*SessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_8b756d20be1364cd, []int{19}
+}
+func (m *SessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionResponse.Unmarshal(m, b)
+}
+func (m *SessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionResponse.Marshal(b, m, deterministic)
+}
+func (dst *SessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionResponse.Merge(dst, src)
+}
+func (m *SessionResponse) XXX_Size() int {
+	return xxx_messageInfo_SessionResponse.Size(m)
+}
+func (m *SessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionResponse proto.InternalMessageInfo
+
+func (m *SessionResponse) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *SessionResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *SessionResponse) GetVariable() string {
+	if m != nil {
+		return m.Variable
+	}
+	return ""
+}
+
+func (m *SessionResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *SessionResponse) GetErrorColumn() int32 {
+	if m != nil {
+		return m.ErrorColumn
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*SumRequest)(nil), "calculator.SumRequest")
 	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
 	proto.RegisterType((*PrimeNumberDecompositionRequest)(nil), "calculator.PrimeNumberDecompositionRequest")
 	proto.RegisterType((*PrimeNumberDecompositionResponse)(nil), "calculator.PrimeNumberDecompositionResponse")
+	proto.RegisterType((*IsPrimeRequest)(nil), "calculator.IsPrimeRequest")
+	proto.RegisterType((*IsPrimeResponse)(nil), "calculator.IsPrimeResponse")
 	proto.RegisterType((*ComputeAverageRequest)(nil), "calculator.ComputeAverageRequest")
 	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
 	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
 	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
 	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
 	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+	proto.RegisterType((*BigIntegerRequest)(nil), "calculator.BigIntegerRequest")
+	proto.RegisterType((*BigIntegerResponse)(nil), "calculator.BigIntegerResponse")
+	proto.RegisterType((*BigRationalRequest)(nil), "calculator.BigRationalRequest")
+	proto.RegisterType((*BigRationalResponse)(nil), "calculator.BigRationalResponse")
+	proto.RegisterType((*EvaluateRequest)(nil), "calculator.EvaluateRequest")
+	proto.RegisterMapType((map[string]float64)(nil), "calculator.EvaluateRequest.VariablesEntry")
+	proto.RegisterType((*EvaluateResponse)(nil), "calculator.EvaluateResponse")
+	proto.RegisterType((*SessionRequest)(nil), "calculator.SessionRequest")
+	proto.RegisterType((*SessionResponse)(nil), "calculator.SessionResponse")
+	proto.RegisterEnum("calculator.BigOperation", BigOperation_name, BigOperation_value)
+	proto.RegisterEnum("calculator.SumRequest_OverflowMode", SumRequest_OverflowMode_name, SumRequest_OverflowMode_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -436,11 +1058,38 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type CalculatorServiceClient interface {
+	// a sum overflowing int32 is sent back as OUT_OF_RANGE, with google.rpc.BadRequest and google.rpc.ErrorInfo details,
+	// unless the request asks for the SATURATE overflow mode
 	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
+	// streams the prime factors in ascending order, found with Pollard's rho
+	// Pollard's rho takes about the square root of a factor in steps to find it, and gives up after 2^22 steps
+	// for numbers of up to 64 bits, proportionally fewer for longer numbers whose steps cost more, or after 10 seconds,
+	// so numbers with at least two prime factors beyond about 13 digits are sent back as RESOURCE_EXHAUSTED,
+	// such as 2^128+1 = 59649589127497217 * 5704689200685129054721. The deadline of the call is honoured too.
 	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
+	// tells if a number is prime with the Miller–Rabin test
+	IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error)
 	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
 	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
+	// error handling
+	// this RPC will throw an exception if the sent number is negative
+	// The error being sent is of type INVALID_ARGUMENT
 	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+	// exact arithmetic on arbitrary-precision integers
+	// malformed numbers and division by zero are sent back as INVALID_ARGUMENT
+	// results too large to compute, from POWER, are sent back as OUT_OF_RANGE
+	BigInteger(ctx context.Context, in *BigIntegerRequest, opts ...grpc.CallOption) (*BigIntegerResponse, error)
+	// exact arithmetic on arbitrary-precision rationals
+	// malformed numbers and division by zero are sent back as INVALID_ARGUMENT
+	// results too large to compute, from POWER, are sent back as OUT_OF_RANGE
+	BigRational(ctx context.Context, in *BigRationalRequest, opts ...grpc.CallOption) (*BigRationalResponse, error)
+	// evaluates an expression
+	// syntax errors, unknown variables, division by zero and expressions too long or too deeply nested
+	// are sent back as INVALID_ARGUMENT, with the column of the error, and too large results as OUT_OF_RANGE
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// runs statements one after the other, sending back the result of each in order
+	// variables and history only live as long as the stream
+	Session(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_SessionClient, error)
 }
 
 type calculatorServiceClient struct {
@@ -492,6 +1141,15 @@ func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDe
 	return m, nil
 }
 
+func (c *calculatorServiceClient) IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error) {
+	out := new(IsPrimeResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/IsPrime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
 	if err != nil {
@@ -566,13 +1224,98 @@ func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRoot
 	return out, nil
 }
 
+func (c *calculatorServiceClient) BigInteger(ctx context.Context, in *BigIntegerRequest, opts ...grpc.CallOption) (*BigIntegerResponse, error) {
+	out := new(BigIntegerResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigInteger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigRational(ctx context.Context, in *BigRationalRequest, opts ...grpc.CallOption) (*BigRationalResponse, error) {
+	out := new(BigRationalResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigRational", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Evaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Session(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_SessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[3], "/calculator.CalculatorService/Session", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceSessionClient{stream}
+	return x, nil
+}
+
+type CalculatorService_SessionClient interface {
+	Send(*SessionRequest) error
+	Recv() (*SessionResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceSessionClient) Send(m *SessionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceSessionClient) Recv() (*SessionResponse, error) {
+	m := new(SessionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CalculatorServiceServer is the server API for CalculatorService service.
 type CalculatorServiceServer interface {
+	// a sum overflowing int32 is sent back as OUT_OF_RANGE, with google.rpc.BadRequest and google.rpc.ErrorInfo details,
+	// unless the request asks for the SATURATE overflow mode
 	Sum(context.Context, *SumRequest) (*SumResponse, error)
+	// streams the prime factors in ascending order, found with Pollard's rho
+	// Pollard's rho takes about the square root of a factor in steps to find it, and gives up after 2^22 steps
+	// for numbers of up to 64 bits, proportionally fewer for longer numbers whose steps cost more, or after 10 seconds,
+	// so numbers with at least two prime factors beyond about 13 digits are sent back as RESOURCE_EXHAUSTED,
+	// such as 2^128+1 = 59649589127497217 * 5704689200685129054721. The deadline of the call is honoured too.
 	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
+	// tells if a number is prime with the Miller–Rabin test
+	IsPrime(context.Context, *IsPrimeRequest) (*IsPrimeResponse, error)
 	ComputeAverage(CalculatorService_ComputeAverageServer) error
 	FindMaximum(CalculatorService_FindMaximumServer) error
+	// error handling
+	// this RPC will throw an exception if the sent number is negative
+	// The error being sent is of type INVALID_ARGUMENT
 	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+	// exact arithmetic on arbitrary-precision integers
+	// malformed numbers and division by zero are sent back as INVALID_ARGUMENT
+	// results too large to compute, from POWER, are sent back as OUT_OF_RANGE
+	BigInteger(context.Context, *BigIntegerRequest) (*BigIntegerResponse, error)
+	// exact arithmetic on arbitrary-precision rationals
+	// malformed numbers and division by zero are sent back as INVALID_ARGUMENT
+	// results too large to compute, from POWER, are sent back as OUT_OF_RANGE
+	BigRational(context.Context, *BigRationalRequest) (*BigRationalResponse, error)
+	// evaluates an expression
+	// syntax errors, unknown variables, division by zero and expressions too long or too deeply nested
+	// are sent back as INVALID_ARGUMENT, with the column of the error, and too large results as OUT_OF_RANGE
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// runs statements one after the other, sending back the result of each in order
+	// variables and history only live as long as the stream
+	Session(CalculatorService_SessionServer) error
 }
 
 func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
@@ -618,6 +1361,24 @@ func (x *calculatorServicePrimeNumberDecompositionServer) Send(m *PrimeNumberDec
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CalculatorService_IsPrime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsPrimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/IsPrime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, req.(*IsPrimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CalculatorService_ComputeAverage_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(CalculatorServiceServer).ComputeAverage(&calculatorServiceComputeAverageServer{stream})
 }
@@ -688,6 +1449,86 @@ func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalculatorService_BigInteger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigIntegerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigInteger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigInteger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigInteger(ctx, req.(*BigIntegerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigRational_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigRationalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigRational(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigRational",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigRational(ctx, req.(*BigRationalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).Session(&calculatorServiceSessionServer{stream})
+}
+
+type CalculatorService_SessionServer interface {
+	Send(*SessionResponse) error
+	Recv() (*SessionRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceSessionServer) Send(m *SessionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceSessionServer) Recv() (*SessionRequest, error) {
+	m := new(SessionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "calculator.CalculatorService",
 	HandlerType: (*CalculatorServiceServer)(nil),
@@ -696,10 +1537,26 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Sum",
 			Handler:    _CalculatorService_Sum_Handler,
 		},
+		{
+			MethodName: "IsPrime",
+			Handler:    _CalculatorService_IsPrime_Handler,
+		},
 		{
 			MethodName: "SquareRoot",
 			Handler:    _CalculatorService_SquareRoot_Handler,
 		},
+		{
+			MethodName: "BigInteger",
+			Handler:    _CalculatorService_BigInteger_Handler,
+		},
+		{
+			MethodName: "BigRational",
+			Handler:    _CalculatorService_BigRational_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _CalculatorService_Evaluate_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -718,42 +1575,85 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Session",
+			Handler:       _CalculatorService_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "calculator/calculatorpb/calculator.proto",
 }
 
 func init() {
-	proto.RegisterFile("calculator/calculatorpb/calculator.proto", fileDescriptor_calculator_0799ea845ca306d9)
-}
-
-var fileDescriptor_calculator_0799ea845ca306d9 = []byte{
-	// 433 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5b, 0x6b, 0xd4, 0x40,
-	0x14, 0x36, 0x2e, 0xad, 0x78, 0x12, 0x0b, 0x3d, 0xe2, 0xba, 0x04, 0xda, 0xed, 0x8e, 0x2f, 0x0b,
-	0x5d, 0xba, 0xa5, 0x22, 0xe8, 0xa3, 0x56, 0xfb, 0x56, 0x91, 0xa4, 0x4f, 0xfa, 0x10, 0xb2, 0xe9,
-	0x54, 0x02, 0x99, 0x4c, 0x3a, 0x97, 0xa2, 0x7f, 0xd1, 0x5f, 0x25, 0x9d, 0xc9, 0x6c, 0x66, 0x2f,
-	0x71, 0x7d, 0xcb, 0xf9, 0xe6, 0xbb, 0x0c, 0x39, 0x1f, 0x03, 0xd3, 0x22, 0xaf, 0x0a, 0x5d, 0xe5,
-	0x8a, 0x8b, 0x79, 0xf7, 0xd9, 0x2c, 0xbc, 0xe1, 0xac, 0x11, 0x5c, 0x71, 0x84, 0x0e, 0x21, 0x37,
-	0x00, 0xa9, 0x66, 0x09, 0xbd, 0xd7, 0x54, 0x2a, 0x9c, 0x40, 0x74, 0x57, 0x0a, 0xa9, 0xb2, 0x5a,
-	0xb3, 0x05, 0x15, 0xa3, 0xe0, 0x24, 0x98, 0xee, 0x25, 0xa1, 0xc1, 0xbe, 0x1a, 0x08, 0xdf, 0xc0,
-	0x0b, 0x49, 0x0b, 0x5e, 0xdf, 0x3a, 0xce, 0x53, 0xc3, 0x89, 0x2c, 0x68, 0x49, 0x64, 0x06, 0xa1,
-	0x71, 0x95, 0x0d, 0xaf, 0x25, 0xc5, 0x23, 0x00, 0xa9, 0x59, 0x26, 0xa8, 0xd4, 0x95, 0x6a, 0x4d,
-	0x9f, 0x4b, 0x43, 0xd0, 0x95, 0x22, 0x1f, 0x60, 0xfc, 0x4d, 0x94, 0x8c, 0x5a, 0xf1, 0x67, 0x5a,
-	0x70, 0xd6, 0x70, 0x59, 0xaa, 0x92, 0xd7, 0xee, 0x62, 0x43, 0xd8, 0xf7, 0xae, 0x34, 0x48, 0xda,
-	0x89, 0x7c, 0x81, 0x93, 0x7e, 0x69, 0x9b, 0x3e, 0x81, 0xa8, 0x79, 0xe4, 0x64, 0x77, 0x79, 0xa1,
-	0xb8, 0x73, 0x08, 0x0d, 0x76, 0x65, 0x20, 0x32, 0x87, 0x57, 0x97, 0x9c, 0x35, 0x5a, 0xd1, 0x8f,
-	0x0f, 0x54, 0xe4, 0x3f, 0xe9, 0xf6, 0xdc, 0xbd, 0x65, 0xee, 0x05, 0x0c, 0xd7, 0x05, 0x6d, 0xda,
-	0x08, 0x9e, 0xe5, 0x16, 0x32, 0x92, 0x20, 0x71, 0x23, 0x99, 0x01, 0x5e, 0x95, 0xf5, 0xed, 0x75,
-	0xfe, 0xab, 0x64, 0xdd, 0x2f, 0xef, 0x4b, 0x98, 0xc3, 0xcb, 0x15, 0x76, 0x67, 0xcf, 0x2c, 0xd4,
-	0xf2, 0xdd, 0x48, 0x4e, 0xe1, 0x30, 0xbd, 0xd7, 0xb9, 0xa0, 0x09, 0xe7, 0x6a, 0x97, 0xfb, 0x3b,
-	0x40, 0x9f, 0xdc, 0x9a, 0x8f, 0x21, 0xb4, 0xe7, 0x99, 0xe0, 0x5c, 0xb5, 0xf7, 0x07, 0x0b, 0x3d,
-	0x12, 0x2f, 0xfe, 0x0c, 0xe0, 0xf0, 0x72, 0x59, 0x9e, 0x94, 0x8a, 0x87, 0xb2, 0xa0, 0xf8, 0x1e,
-	0x06, 0xa9, 0x66, 0x38, 0x3c, 0xf3, 0x9a, 0xd6, 0x95, 0x2a, 0x7e, 0xbd, 0x81, 0xdb, 0x38, 0xf2,
-	0x04, 0x7f, 0xc3, 0xa8, 0x6f, 0x7d, 0x78, 0xea, 0xcb, 0x76, 0xf4, 0x23, 0x9e, 0xfd, 0x1f, 0xd9,
-	0x05, 0x9f, 0x07, 0xf8, 0x03, 0x0e, 0x56, 0x37, 0x88, 0x13, 0xdf, 0x63, 0x6b, 0x1d, 0x62, 0xf2,
-	0x2f, 0x8a, 0x33, 0x9f, 0x06, 0x78, 0x03, 0xa1, 0xb7, 0x3c, 0x3c, 0xf6, 0x65, 0x9b, 0x1d, 0x88,
-	0xc7, 0xbd, 0xe7, 0x9d, 0xe7, 0x79, 0x80, 0xd7, 0x00, 0xdd, 0xd2, 0xf0, 0x68, 0xe5, 0xb7, 0xae,
-	0x6f, 0x3e, 0x3e, 0xee, 0x3b, 0x76, 0x96, 0x9f, 0x0e, 0xbe, 0x47, 0xfe, 0x3b, 0xb1, 0xd8, 0x37,
-	0xaf, 0xc3, 0xdb, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x9e, 0x96, 0xbb, 0x6e, 0x49, 0x04, 0x00,
-	0x00,
+	proto.RegisterFile("calculator/calculatorpb/calculator.proto", fileDescriptor_calculator_8b756d20be1364cd)
+}
+
+var fileDescriptor_calculator_8b756d20be1364cd = []byte{
+	// 1033 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0x4b, 0x6f, 0xdb, 0x46,
+	0x10, 0x36, 0xad, 0xe8, 0xc1, 0x91, 0x22, 0xd3, 0x9b, 0xd6, 0x55, 0xe8, 0xf8, 0xc5, 0x1c, 0x2a,
+	0x38, 0x86, 0x1d, 0xb8, 0x68, 0x11, 0x14, 0xbd, 0xe8, 0x65, 0x5b, 0x85, 0x65, 0x09, 0x2b, 0x29,
+	0x7d, 0xa1, 0x10, 0x28, 0x6a, 0x2d, 0x10, 0x25, 0xb5, 0xca, 0x92, 0x54, 0xed, 0xdf, 0x51, 0xa0,
+	0x3f, 0xa4, 0xb7, 0xde, 0x0b, 0xf4, 0x6f, 0x15, 0x5c, 0xbe, 0x96, 0xb2, 0x64, 0x07, 0xe8, 0x25,
+	0x37, 0xce, 0xcc, 0x37, 0xdf, 0x3c, 0x76, 0x77, 0x86, 0x50, 0x35, 0x74, 0xcb, 0xf0, 0x2c, 0xdd,
+	0xa5, 0xec, 0x2c, 0xf9, 0x9c, 0x8f, 0x05, 0xe1, 0x74, 0xce, 0xa8, 0x4b, 0x11, 0x24, 0x1a, 0xed,
+	0x5f, 0x09, 0xa0, 0xef, 0xd9, 0x98, 0x7c, 0xf0, 0x88, 0xe3, 0xa2, 0x23, 0x28, 0xdd, 0x9a, 0xcc,
+	0x71, 0x47, 0x33, 0xcf, 0x1e, 0x13, 0x56, 0x91, 0x0e, 0xa5, 0x6a, 0x16, 0x17, 0xb9, 0xee, 0x86,
+	0xab, 0xd0, 0x6b, 0x78, 0xee, 0x10, 0x83, 0xce, 0x26, 0x11, 0x66, 0x93, 0x63, 0x4a, 0x81, 0x32,
+	0x04, 0x5d, 0xc1, 0x73, 0xba, 0x20, 0xec, 0xd6, 0xa2, 0xbf, 0x8f, 0x6c, 0x3a, 0x21, 0x95, 0xcc,
+	0xa1, 0x54, 0x2d, 0x9f, 0xbf, 0x3e, 0x15, 0x92, 0x49, 0xc2, 0x9e, 0x76, 0x43, 0x6c, 0x87, 0x4e,
+	0x08, 0x2e, 0x51, 0x41, 0xd2, 0xbe, 0x84, 0x92, 0x68, 0x45, 0x32, 0x64, 0x5b, 0x18, 0x77, 0xb1,
+	0xb2, 0x81, 0x4a, 0x50, 0xe8, 0xd7, 0x06, 0x43, 0x5c, 0x1b, 0xb4, 0x14, 0x49, 0xfb, 0x1e, 0x8a,
+	0x9c, 0xd1, 0x99, 0xd3, 0x99, 0x43, 0xd0, 0x1e, 0x80, 0xe3, 0xd9, 0x23, 0x46, 0x1c, 0xcf, 0x72,
+	0xc3, 0x3a, 0x64, 0x87, 0x03, 0x3c, 0xcb, 0x45, 0xaf, 0x40, 0x76, 0x74, 0xd7, 0x63, 0xba, 0x4b,
+	0x26, 0xbc, 0x82, 0x02, 0x4e, 0x14, 0xda, 0x8f, 0x70, 0xd0, 0x63, 0xa6, 0x4d, 0x82, 0x6a, 0x9a,
+	0xc4, 0xa0, 0xf6, 0x9c, 0x3a, 0xa6, 0x6b, 0xd2, 0x59, 0xd4, 0xa9, 0x1d, 0xc8, 0x09, 0x3d, 0xca,
+	0xe0, 0x50, 0xf2, 0xe3, 0x8e, 0xcd, 0xa9, 0xd8, 0x1b, 0x19, 0xcb, 0x63, 0x73, 0x1a, 0x50, 0x69,
+	0x14, 0x0e, 0xd7, 0x33, 0x87, 0xa9, 0x1f, 0x41, 0x69, 0xee, 0x63, 0x46, 0xb7, 0xba, 0xe1, 0xd2,
+	0x28, 0x40, 0x91, 0xeb, 0x2e, 0xb8, 0x0a, 0x55, 0x41, 0xf1, 0xa3, 0xa4, 0x60, 0x41, 0xac, 0xf2,
+	0xd8, 0x9c, 0xf6, 0x12, 0xa4, 0x76, 0x09, 0xe5, 0xb6, 0xc3, 0x15, 0xff, 0x33, 0xf3, 0x13, 0xd8,
+	0x8a, 0x89, 0xc2, 0x44, 0x5f, 0x42, 0xc1, 0x74, 0x82, 0x24, 0x38, 0x57, 0x01, 0xe7, 0xcd, 0x00,
+	0xa2, 0x9d, 0xc1, 0xe7, 0x0d, 0x6a, 0xcf, 0x3d, 0x97, 0xd4, 0x16, 0x84, 0xe9, 0xd3, 0x35, 0xd1,
+	0xb3, 0x51, 0x74, 0xed, 0x1c, 0x76, 0x96, 0x1d, 0xc2, 0x28, 0x15, 0xc8, 0xeb, 0x81, 0x8a, 0xbb,
+	0x48, 0x38, 0x12, 0xb5, 0x13, 0x40, 0x17, 0xe6, 0x6c, 0xd2, 0xd1, 0xef, 0x4c, 0xdb, 0xb3, 0x9f,
+	0x8a, 0x70, 0x06, 0x2f, 0x52, 0xe8, 0x84, 0xde, 0x0e, 0x54, 0x21, 0x3e, 0x12, 0xb5, 0x37, 0xb0,
+	0xdd, 0xff, 0xe0, 0xe9, 0x8c, 0x60, 0x4a, 0xdd, 0xa7, 0xd8, 0xbf, 0x06, 0x24, 0x82, 0x43, 0xf2,
+	0x03, 0x28, 0x06, 0xf6, 0x11, 0xa3, 0xd4, 0x0d, 0xf3, 0x87, 0x40, 0xe5, 0x03, 0xb5, 0x3f, 0x24,
+	0xd8, 0xae, 0x9b, 0xd3, 0xf6, 0xcc, 0x25, 0x53, 0xc2, 0x1e, 0x7b, 0x86, 0xf2, 0x47, 0x3c, 0x43,
+	0x79, 0xe9, 0x19, 0x7e, 0x03, 0x32, 0x9d, 0x13, 0xa6, 0xfb, 0xd7, 0x2b, 0x7c, 0x82, 0x15, 0xf1,
+	0x09, 0xd6, 0xcd, 0x69, 0x37, 0xb2, 0xe3, 0x04, 0xea, 0x37, 0x56, 0x4c, 0x2a, 0x2c, 0x66, 0x07,
+	0x72, 0xc2, 0x73, 0x92, 0x71, 0x28, 0x69, 0x7f, 0x49, 0x1c, 0x8e, 0xb9, 0xaf, 0x6e, 0x7d, 0x22,
+	0x45, 0xf8, 0x4f, 0x7c, 0xce, 0x88, 0x61, 0x3a, 0xbe, 0xdf, 0xb3, 0x60, 0x00, 0xc4, 0x0a, 0xed,
+	0x57, 0x78, 0x91, 0xca, 0xf9, 0xf1, 0x1a, 0xfd, 0x5b, 0x32, 0x21, 0x86, 0x69, 0xeb, 0x56, 0x98,
+	0x63, 0x24, 0xa2, 0xcf, 0x20, 0x4b, 0xee, 0x74, 0xc3, 0xe5, 0xa9, 0x15, 0x70, 0x20, 0x68, 0x7f,
+	0x4b, 0xb0, 0xd5, 0x5a, 0xe8, 0x96, 0xa7, 0xbb, 0xf1, 0xd5, 0xdf, 0x07, 0x20, 0x77, 0x73, 0x46,
+	0x1c, 0x9e, 0x51, 0xc0, 0x2f, 0x68, 0xd0, 0x15, 0xc8, 0x0b, 0x9d, 0x99, 0xfa, 0xd8, 0x22, 0x4e,
+	0x65, 0xf3, 0x30, 0x53, 0x2d, 0x9e, 0x1f, 0x8b, 0x85, 0x2e, 0xf1, 0x9d, 0xbe, 0x8f, 0xc0, 0xad,
+	0x99, 0xcb, 0xee, 0x71, 0xe2, 0xac, 0x7e, 0x07, 0xe5, 0xb4, 0x11, 0x29, 0x90, 0xf9, 0x8d, 0xdc,
+	0x87, 0x41, 0xfd, 0x4f, 0x3f, 0x6f, 0x9f, 0x8f, 0xf0, 0x7a, 0x24, 0x1c, 0x08, 0xdf, 0x6e, 0xbe,
+	0x93, 0xb4, 0x63, 0x50, 0x92, 0x50, 0x2b, 0xfb, 0x22, 0xc5, 0x67, 0x7f, 0x0a, 0xe5, 0x7e, 0x90,
+	0x7e, 0x54, 0xa5, 0x3f, 0x59, 0x5d, 0xdd, 0x25, 0x36, 0x99, 0x45, 0x4d, 0x4c, 0x14, 0xda, 0x9f,
+	0x12, 0x6c, 0xc5, 0x0e, 0x09, 0xf7, 0xca, 0x81, 0x94, 0xc4, 0xdc, 0x14, 0x63, 0x22, 0x15, 0x0a,
+	0x51, 0xa9, 0xbc, 0xe9, 0x32, 0x8e, 0x65, 0x7e, 0x1a, 0x8c, 0x51, 0xc6, 0x0f, 0x5c, 0xc6, 0x81,
+	0xe0, 0x5f, 0x45, 0xfe, 0x31, 0x32, 0xa8, 0xe5, 0xd9, 0xb3, 0x4a, 0x36, 0x58, 0x6b, 0x5c, 0xd7,
+	0xe0, 0xaa, 0xe3, 0x05, 0x94, 0xc4, 0x8b, 0x84, 0xf6, 0xe0, 0x65, 0xbd, 0x7d, 0x39, 0xea, 0xf6,
+	0x5a, 0xb8, 0x36, 0x68, 0x77, 0x6f, 0x46, 0xc3, 0x9b, 0x7e, 0xaf, 0xd5, 0x68, 0x5f, 0xb4, 0x5b,
+	0x4d, 0x65, 0x03, 0xe5, 0x21, 0xd3, 0x1f, 0x76, 0x14, 0x89, 0x2f, 0xa1, 0x61, 0x7d, 0x80, 0x6b,
+	0x8d, 0x81, 0xb2, 0xe9, 0x4b, 0x9d, 0xe1, 0xf5, 0xa0, 0xdd, 0xbb, 0xfe, 0x49, 0xc9, 0x20, 0x80,
+	0x5c, 0xb3, 0xfd, 0xbe, 0xdd, 0x6c, 0x29, 0xcf, 0xfc, 0xbd, 0xd5, 0xeb, 0xfe, 0xd0, 0xc2, 0x4a,
+	0x16, 0x15, 0x21, 0xdf, 0xe9, 0x36, 0x87, 0xd7, 0xc3, 0xbe, 0x92, 0x3b, 0xff, 0x27, 0x07, 0xdb,
+	0x8d, 0xf8, 0x8c, 0xfb, 0x84, 0x2d, 0x4c, 0x83, 0xa0, 0x77, 0x90, 0xe9, 0x7b, 0x36, 0xda, 0x59,
+	0xbd, 0x2f, 0xd5, 0x2f, 0x1e, 0xe8, 0x83, 0x56, 0x6a, 0x1b, 0xe8, 0x1e, 0x2a, 0xeb, 0x16, 0x0c,
+	0x7a, 0x23, 0xba, 0x3d, 0xb1, 0xe0, 0xd4, 0x93, 0x8f, 0x03, 0x47, 0x81, 0xdf, 0x4a, 0xa8, 0x09,
+	0xf9, 0x70, 0x43, 0x20, 0x55, 0x74, 0x4e, 0xef, 0x1f, 0x75, 0x77, 0xa5, 0x2d, 0x2e, 0xe0, 0x17,
+	0x28, 0xa7, 0x17, 0x01, 0x3a, 0x12, 0x1d, 0x56, 0x6e, 0x15, 0x55, 0x7b, 0x0c, 0x12, 0x51, 0x57,
+	0x25, 0x34, 0x80, 0xa2, 0xb0, 0x03, 0xd0, 0xbe, 0xe8, 0xf6, 0x70, 0x95, 0xa8, 0x07, 0x6b, 0xed,
+	0x09, 0xe7, 0x5b, 0x09, 0x75, 0x00, 0x92, 0xd9, 0x8f, 0xf6, 0x52, 0x87, 0xb3, 0xbc, 0x40, 0xd4,
+	0xfd, 0x75, 0xe6, 0xb8, 0x03, 0x1d, 0x80, 0x64, 0xfa, 0xa6, 0xe9, 0x1e, 0xac, 0x0a, 0x75, 0x7f,
+	0x9d, 0x39, 0xa6, 0xeb, 0x41, 0x51, 0x98, 0x74, 0x68, 0xd9, 0x61, 0x69, 0x6c, 0xab, 0x07, 0x6b,
+	0xed, 0x31, 0xe3, 0x25, 0x14, 0xa2, 0x01, 0x81, 0x76, 0x1f, 0x99, 0x50, 0xea, 0xab, 0xd5, 0xc6,
+	0x98, 0xe8, 0x0a, 0xf2, 0xe1, 0x30, 0x48, 0xdf, 0x98, 0xf4, 0x48, 0x51, 0x77, 0x57, 0xda, 0xc4,
+	0x23, 0xa8, 0x97, 0x7f, 0x2e, 0x89, 0x3f, 0xbd, 0xe3, 0x1c, 0xff, 0xd5, 0xfd, 0xea, 0xbf, 0x01,
+	0x00, 0xf6, 0x27, 0xe9, 0xbe, 0x16, 0x0b, 0x00, 0x00,
 }
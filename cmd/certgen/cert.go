@@ -0,0 +1,188 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// clockSkew backdates certificates so peers with a slightly late clock accept them right away
+const clockSkew = 5 * time.Minute
+
+// generateKey returns a new private key of the given type: rsa, ecdsa (P-256) or ed25519
+func generateKey(keyType string, rsaBits int) (crypto.Signer, error) {
+	switch keyType {
+	case "rsa":
+		return rsa.GenerateKey(rand.Reader, rsaBits)
+	case "ecdsa":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ed25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unknown key type %q, expected rsa, ecdsa or ed25519", keyType)
+	}
+}
+
+// subjectAltNames holds the SANs of a certificate, sorted by kind
+type subjectAltNames struct {
+	DNSNames       []string
+	IPAddresses    []net.IP
+	URIs           []*url.URL
+	EmailAddresses []string
+}
+
+// parseSANs reads a comma separated list of SANs, telling IPs, URIs ("scheme://..."),
+// emails ("user@host") and DNS names apart by their shape
+func parseSANs(list string) (*subjectAltNames, error) {
+	sans := &subjectAltNames{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case net.ParseIP(name) != nil:
+			sans.IPAddresses = append(sans.IPAddresses, net.ParseIP(name))
+		case strings.Contains(name, "://"):
+			uri, err := url.Parse(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid URI SAN %q: %v", name, err)
+			}
+			sans.URIs = append(sans.URIs, uri)
+		case strings.Contains(name, "@"):
+			sans.EmailAddresses = append(sans.EmailAddresses, name)
+		default:
+			sans.DNSNames = append(sans.DNSNames, name)
+		}
+	}
+	return sans, nil
+}
+
+func randomSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// newCA returns a self-signed certificate authority that can only sign leaf certificates
+func newCA(commonName string, key crypto.Signer, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// newLeaf returns a certificate for key signed by the CA, usable for extKeyUsage.
+// It never outlives the CA, which would make it unverifiable before its own expiry.
+func newLeaf(commonName string, sans *subjectAltNames, extKeyUsage x509.ExtKeyUsage, key crypto.Signer, validity time.Duration, ca *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, error) {
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(ca.NotAfter) {
+		notAfter = ca.NotAfter
+	}
+	keyUsage := x509.KeyUsageDigitalSignature
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              notAfter,
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           []x509.ExtKeyUsage{extKeyUsage},
+		BasicConstraintsValid: true,
+		DNSNames:              sans.DNSNames,
+		IPAddresses:           sans.IPAddresses,
+		URIs:                  sans.URIs,
+		EmailAddresses:        sans.EmailAddresses,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+func writeCert(file string, cert *x509.Certificate) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// writeKey writes key as an unencrypted PKCS#8 PEM file only readable by its owner
+func writeKey(file string, key crypto.Signer) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the permissions of a file it overwrites
+	return os.Chmod(file, 0600)
+}
+
+func readCert(file string) (*x509.Certificate, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate found in %v", file)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// readKey reads an unencrypted PKCS#8 private key, such as the ones writeKey writes
+func readKey(file string) (crypto.Signer, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("no unencrypted PKCS#8 private key found in %v", file)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key in %v", file)
+	}
+	return signer, nil
+}
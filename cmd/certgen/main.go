@@ -0,0 +1,184 @@
+// Command certgen creates the certificates the servers and clients use for TLS and mutual TLS:
+// a certificate authority, a server certificate and a client certificate signed by it,
+// plus the secret of development JWTs when missing.
+// The keys, certificates and secret are not part of the repository, so every checkout needs to run it once before starting the servers.
+// It only ever signs with a CA whose key belongs to the current user and is unreadable by others,
+// so a CA copied from another checkout or left by another user is refused rather than reused.
+//
+// Run it from the root of the repository to create everything in ssl/:
+//
+//	go run ./cmd/certgen
+//
+// Leaf certificates can be rotated while keeping the CA, so clients and servers keep trusting each other:
+//
+//	go run ./cmd/certgen -rotate server
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// leaf describes one of the certificates signed by the CA
+type leaf struct {
+	Name        string // base name of the files, e.g. server.crt and server.pem
+	CommonName  string
+	SANs        string
+	ExtKeyUsage x509.ExtKeyUsage
+}
+
+func main() {
+	dir := flag.String("dir", "ssl", "directory the certificates are written to")
+	keyType := flag.String("key-type", "rsa", "type of the generated keys: rsa, ecdsa (P-256) or ed25519")
+	rsaBits := flag.Int("rsa-bits", 4096, "size of the generated RSA keys")
+	caCN := flag.String("ca-cn", "grpc-go-course CA", "common name of the certificate authority")
+	caValidity := flag.Duration("ca-validity", 10*365*24*time.Hour, "how long the certificate authority is valid")
+	validity := flag.Duration("validity", 365*24*time.Hour, "how long the server and client certificates are valid, at most as long as the CA")
+	serverCN := flag.String("server-cn", "localhost", "common name of the server certificate")
+	serverSANs := flag.String("server-san", "localhost,127.0.0.1,::1", "comma separated DNS names, IPs, URIs and emails of the server certificate")
+	clientCN := flag.String("client-cn", "grpc-go-course-client", "common name of the client certificate")
+	clientSANs := flag.String("client-san", "spiffe://grpc-go-course/client", "comma separated DNS names, IPs, URIs and emails of the client certificate, the first one being the identity of the client")
+	rotate := flag.String("rotate", "", "comma separated certificates to reissue with the existing CA: server, client")
+	force := flag.Bool("force", false, "replace an existing CA, which every server and client must then be given")
+	flag.Parse()
+
+	leaves := map[string]*leaf{
+		"server": {Name: "server", CommonName: *serverCN, SANs: *serverSANs, ExtKeyUsage: x509.ExtKeyUsageServerAuth},
+		"client": {Name: "client", CommonName: *clientCN, SANs: *clientSANs, ExtKeyUsage: x509.ExtKeyUsageClientAuth},
+	}
+
+	caCertFile := filepath.Join(*dir, "ca.crt")
+	caKeyFile := filepath.Join(*dir, "ca.key")
+
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		log.Fatalf("Error while creating %v: %v", *dir, err)
+	}
+	// the JWT secret comes first, it is needed even where the certificates already exist
+	if err := writeJWTSecret(filepath.Join(*dir, "jwt_secret")); err != nil {
+		log.Fatalf("Error while writing the JWT secret: %v", err)
+	}
+
+	var issue []*leaf
+	if *rotate == "" {
+		if _, err := os.Stat(caCertFile); err == nil && !*force {
+			if _, _, err := loadCA(caCertFile, caKeyFile); err != nil {
+				log.Fatalf("Refusing to keep the CA in %v: %v\nUse -force to replace it with a new CA of your own", caCertFile, err)
+			}
+			fmt.Printf("Keeping the existing CA in %v, use -rotate to reissue certificates with it or -force to replace it\n", caCertFile)
+			return
+		}
+		// a CA left by someone else may not be overwritable in place, but it can be replaced
+		for _, file := range []string{caKeyFile, caCertFile} {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				log.Fatalf("Error while removing the old CA: %v", err)
+			}
+		}
+		caKey, err := generateKey(*keyType, *rsaBits)
+		if err != nil {
+			log.Fatalf("Error while generating the CA key: %v", err)
+		}
+		ca, err := newCA(*caCN, caKey, *caValidity)
+		if err != nil {
+			log.Fatalf("Error while creating the CA: %v", err)
+		}
+		if err := writeKey(caKeyFile, caKey); err != nil {
+			log.Fatalf("Error while writing the CA key: %v", err)
+		}
+		if err := writeCert(caCertFile, ca); err != nil {
+			log.Fatalf("Error while writing the CA: %v", err)
+		}
+		fmt.Printf("Created CA %q in %v, valid until %v\n", *caCN, caCertFile, ca.NotAfter.Format(time.RFC3339))
+		issue = []*leaf{leaves["server"], leaves["client"]}
+	} else {
+		for _, name := range strings.Split(*rotate, ",") {
+			l, ok := leaves[strings.TrimSpace(name)]
+			if !ok {
+				log.Fatalf("Unknown certificate %q to rotate, expected server or client", name)
+			}
+			issue = append(issue, l)
+		}
+	}
+
+	ca, caKey, err := loadCA(caCertFile, caKeyFile)
+	if err != nil {
+		log.Fatalf("Refusing to sign with the CA in %v: %v\nRun without -rotate and with -force to create a new CA of your own", caCertFile, err)
+	}
+
+	for _, l := range issue {
+		sans, err := parseSANs(l.SANs)
+		if err != nil {
+			log.Fatalf("Error in the SANs of the %v certificate: %v", l.Name, err)
+		}
+		key, err := generateKey(*keyType, *rsaBits)
+		if err != nil {
+			log.Fatalf("Error while generating the %v key: %v", l.Name, err)
+		}
+		cert, err := newLeaf(l.CommonName, sans, l.ExtKeyUsage, key, *validity, ca, caKey)
+		if err != nil {
+			log.Fatalf("Error while creating the %v certificate: %v", l.Name, err)
+		}
+		// the key first, so a failure never leaves a certificate next to a key it does not match
+		if err := writeKey(filepath.Join(*dir, l.Name+".pem"), key); err != nil {
+			log.Fatalf("Error while writing the %v key: %v", l.Name, err)
+		}
+		if err := writeCert(filepath.Join(*dir, l.Name+".crt"), cert); err != nil {
+			log.Fatalf("Error while writing the %v certificate: %v", l.Name, err)
+		}
+		fmt.Printf("Created %v certificate %q in %v, valid until %v\n", l.Name, l.CommonName, filepath.Join(*dir, l.Name+".crt"), cert.NotAfter.Format(time.RFC3339))
+	}
+
+}
+
+// loadCA reads the CA certificate and key, and checks they are a pair this user created:
+// the key must be private to the current user and match the certificate, which must still be a valid CA.
+// Every checkout creates its own CA, so a CA that fails these checks was copied from elsewhere or shared.
+func loadCA(certFile, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	if err := checkPrivate(keyFile); err != nil {
+		return nil, nil, err
+	}
+	ca, err := readCert(certFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := readKey(keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if public, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !public.Equal(ca.PublicKey) {
+		return nil, nil, fmt.Errorf("the key in %v does not match the CA certificate %v", keyFile, certFile)
+	}
+	if !ca.IsCA {
+		return nil, nil, fmt.Errorf("%v is not a certificate authority", certFile)
+	}
+	if time.Now().After(ca.NotAfter) {
+		return nil, nil, fmt.Errorf("the CA expired on %v", ca.NotAfter.Format(time.RFC3339))
+	}
+	return ca, key, nil
+}
+
+// writeJWTSecret creates the HS256 secret the servers and clients share, keeping an existing one
+// so tokens signed with it stay valid
+func writeJWTSecret(file string) error {
+	if _, err := os.Stat(file); err == nil {
+		return nil
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(file, []byte(hex.EncodeToString(secret)+"\n"), 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Created JWT secret in %v\n", file)
+	return nil
+}
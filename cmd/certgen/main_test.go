@@ -0,0 +1,61 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestLoadCA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key")
+	otherKeyFile := filepath.Join(dir, "other.key")
+
+	key, err := generateKey("ecdsa", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := newCA("test CA", key, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := generateKey("ed25519", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeCert(certFile, ca); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKey(keyFile, key); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKey(otherKeyFile, otherKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _, err := loadCA(certFile, keyFile); err != nil || !got.Equal(ca) {
+		t.Fatalf("loadCA() = %v, want the CA", err)
+	}
+	if _, _, err := loadCA(certFile, otherKeyFile); err == nil {
+		t.Error("loadCA() with the key of another CA succeeded")
+	}
+	if _, _, err := loadCA(certFile, filepath.Join(dir, "missing.key")); err == nil {
+		t.Error("loadCA() without a key succeeded")
+	}
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if err := os.Chmod(keyFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := loadCA(certFile, keyFile); err == nil {
+		t.Error("loadCA() with a key readable by other users succeeded")
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkPrivate makes sure a key file belongs to the current user and nobody else can read or change it,
+// so a key copied from elsewhere or shared with other users is never used to sign certificates
+func checkPrivate(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%v belongs to user %v, not to you", file, stat.Uid)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		return fmt.Errorf("%v can be accessed by other users (mode %v)", file, perm)
+	}
+	return nil
+}
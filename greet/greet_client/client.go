@@ -2,14 +2,15 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"time"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
 
+	"github.com/simplesteph/grpc-go-course/auth"
 	"github.com/simplesteph/grpc-go-course/greet/greetpb"
 
 	"google.golang.org/grpc"
@@ -17,22 +18,22 @@ import (
 )
 
 func main() {
+	token := flag.String("token", "", "JWT sent to the server, a development token is signed with -jwt-secret when empty")
+	user := flag.String("user", "Stephane", "subject of the development token")
+	authFlags := auth.RegisterClientFlags(flag.CommandLine)
+	flag.Parse()
 
 	fmt.Println("Hello I'm a client")
 
-	tls := false
-	opts := grpc.WithInsecure()
-	if tls {
-		certFile := "ssl/ca.crt" // Certificate Authority Trust certificate
-		creds, sslErr := credentials.NewClientTLSFromFile(certFile, "")
-		if sslErr != nil {
-			log.Fatalf("Error while loading CA trust certificate: %v", sslErr)
-			return
-		}
-		opts = grpc.WithTransportCredentials(creds)
+	tok, err := authFlags.Token(*token, *user)
+	if err != nil {
+		log.Fatalf("Error while signing a development token: %v", err)
 	}
-
-	cc, err := grpc.Dial("localhost:50051", opts)
+	opts, err := auth.DialOptions(authFlags, tok)
+	if err != nil {
+		log.Fatalf("Error while loading certificates: %v", err)
+	}
+	cc, err := grpc.Dial("localhost:50051", opts...)
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -10,9 +11,9 @@ import (
 	"time"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
+	"github.com/simplesteph/grpc-go-course/auth"
 	"github.com/simplesteph/grpc-go-course/greet/greetpb"
 
 	"google.golang.org/grpc"
@@ -109,6 +110,9 @@ func (*server) GreetWithDeadline(ctx context.Context, req *greetpb.GreetWithDead
 }
 
 func main() {
+	authFlags := auth.RegisterServerFlags(flag.CommandLine)
+	flag.Parse()
+
 	fmt.Println("Hello world")
 
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
@@ -116,17 +120,9 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	opts := []grpc.ServerOption{}
-	tls := false
-	if tls {
-		certFile := "ssl/server.crt"
-		keyFile := "ssl/server.pem"
-		creds, sslErr := credentials.NewServerTLSFromFile(certFile, keyFile)
-		if sslErr != nil {
-			log.Fatalf("Failed loading certificates: %v", sslErr)
-			return
-		}
-		opts = append(opts, grpc.Creds(creds))
+	opts, err := auth.ServerOptions(context.Background(), authFlags)
+	if err != nil {
+		log.Fatalf("Failed setting up authentication: %v", err)
 	}
 
 	s := grpc.NewServer(opts...)